@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -13,16 +12,17 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/apikey"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/setup"
-	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/transport"
 )
 
 // Build-time variables.
 var (
 	appVersion string //nolint:gochecknoglobals
-
-	ErrUnknownTransportType = errors.New("unknown transport type")
 )
 
 const (
@@ -56,75 +56,106 @@ func main() {
 	slog.Info("starting up...")
 	slog.Info("using config", "config", cfg) // hides sensitive values
 
-	srv, errChan, err := startServer(cfg)
+	telemetry, err := setup.NewTelemetry(cfg)
+	if err != nil {
+		slog.Error("error setting up telemetry", "error", err)
+
+		return
+	}
+
+	group, errChan, err := startServer(rootCtx, cfg, telemetry)
 	if err != nil {
 		slog.Error("error starting server", "error", err)
+		shutdownTelemetry(telemetry)
 
 		return
 	}
 
-	waitForShutdown(rootCtx, errChan)
-	shutdownServer(srv)
+	waitForShutdown(rootCtx, errChan, group.Stopped())
+	shutdownServer(group)
+	shutdownTelemetry(telemetry)
 
 	slog.Info("goodbye!")
 }
 
-func startServer(cfg config.App) (*server.SSEServer, chan error, error) {
-	mcpServer := server.NewMCPServer(
-		setup.AppName,
-		cfg.AppVersion,
+func startServer(
+	ctx context.Context,
+	cfg config.App,
+	telemetry setup.Telemetry,
+) (*transport.Group, <-chan error, error) {
+	hooks := &server.Hooks{}
+
+	serverOpts := []server.ServerOption{
 		server.WithLogging(),
 		server.WithRecovery(),
-	)
+		server.WithHooks(hooks),
+	}
 
-	if err := setup.MCPTools(cfg, mcpServer); err != nil {
-		return nil, nil, fmt.Errorf("failed to register tools: %w", err)
+	if clientKeyHeaders := cfg.ClientKeyHeaders(); len(clientKeyHeaders) > 0 {
+		sessions := apikey.NewSessions(clientKeyHeaders)
+		sessions.AddHooks(hooks)
+
+		serverOpts = append(serverOpts,
+			server.WithToolHandlerMiddleware(sessions.ToolMiddleware),
+			server.WithPromptHandlerMiddleware(sessions.PromptMiddleware),
+			server.WithResourceHandlerMiddleware(sessions.ResourceMiddleware),
+		)
 	}
 
-	errChan := make(chan error, 1)
+	if cfg.Auth.OAuth.Enabled() {
+		serverOpts = append(serverOpts, server.WithToolFilter(auth.ToolFilter(cfg.Auth.OAuth.ToolScopes)))
+	}
 
-	switch cfg.Transport {
-	case config.TransportStdio:
-		go func() {
-			slog.Info("starting stdio server")
+	if cfg.Prompts.Enabled {
+		serverOpts = append(serverOpts, server.WithPromptCapabilities(true))
+	}
 
-			if err := server.ServeStdio(mcpServer); err != nil {
-				errChan <- fmt.Errorf("stdio server error: %w", err)
-			}
-		}()
+	if cfg.Resources.Enabled {
+		serverOpts = append(serverOpts, server.WithResourceCapabilities(cfg.Resources.Subscriptions.Enabled, true))
+	}
 
-		return nil, errChan, nil
+	mcpServer := server.NewMCPServer(
+		setup.AppName,
+		cfg.AppVersion,
+		serverOpts...,
+	)
 
-	case config.TransportSSE:
-		sseServer := server.NewSSEServer(
-			mcpServer,
-			[]server.SSEOption{
-				server.WithSSEContextFunc(middleware.WithHTTPRequestLogging),
-			}...,
-		)
+	upstream := setup.Upstream{Telemetry: telemetry, Breakers: breaker.New()}
+
+	if err := setup.MCPTools(cfg, mcpServer, upstream); err != nil {
+		return nil, nil, fmt.Errorf("failed to register tools: %w", err)
+	}
 
-		go func() {
-			slog.Info("starting sse server", "address", cfg.TransportSSE.Address)
+	if err := setup.MCPPrompts(ctx, cfg, mcpServer, upstream); err != nil {
+		return nil, nil, fmt.Errorf("failed to register prompts: %w", err)
+	}
 
-			if err := sseServer.Start(cfg.TransportSSE.Address); err != nil {
-				errChan <- fmt.Errorf("sse server error: %w", err)
-			}
-		}()
+	if err := setup.MCPResources(ctx, cfg, mcpServer, hooks, upstream); err != nil {
+		return nil, nil, fmt.Errorf("failed to register resources: %w", err)
+	}
 
-		return sseServer, errChan, nil
+	checker, err := setup.HealthChecker(cfg, upstream)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up health checks: %w", err)
+	}
 
-	default:
-		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownTransportType, cfg.Transport)
+	group, err := transport.NewGroup(cfg, mcpServer, checker, telemetry.Metrics)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up transports: %w", err)
 	}
+
+	return group, group.Start(), nil
 }
 
-func waitForShutdown(ctx context.Context, errChan chan error) {
+func waitForShutdown(ctx context.Context, errChan <-chan error, stopped <-chan struct{}) {
 	osSignals := make(chan os.Signal, 1)
 	signal.Notify(osSignals, syscall.SIGINT, syscall.SIGTERM)
 
 	select {
 	case err := <-errChan:
 		slog.Error("server error", "error", err)
+	case <-stopped:
+		slog.Info("every transport has stopped")
 	case sig := <-osSignals:
 		slog.Info("signal received from os", "signal", sig)
 	case <-ctx.Done():
@@ -132,22 +163,16 @@ func waitForShutdown(ctx context.Context, errChan chan error) {
 	}
 }
 
-func shutdownServer(srv *server.SSEServer) {
-	// `srv` is nil if we're using the stdio transport.
-	if srv == nil {
-		return
-	}
-
+func shutdownServer(group *transport.Group) {
 	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
 	defer cancel()
 
-	slog.Info("shutting down sse server...")
-
-	if err := srv.Shutdown(ctx); err != nil {
-		slog.Warn("sse server shutdown error", "error", err)
+	group.Shutdown(ctx)
+}
 
-		return
-	}
+func shutdownTelemetry(telemetry setup.Telemetry) {
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
 
-	slog.Info("sse server has been shut down gracefully")
+	telemetry.Shutdown(ctx)
 }
@@ -0,0 +1,80 @@
+// Package apikey decides which Portkey API key is used for each request: the server's own, or one provided by the
+// connecting client, so that Portkey attributes logs, permissions and budgets to the right person.
+package apikey
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+var ErrClientKeyRequired = errors.New("a portkey api key must be provided by the client")
+
+type ctxKey string
+
+const clientKey = ctxKey("client_api_keys")
+
+// ContextWithClientKey returns a copy of the context carrying the Portkey API key that the client provided for the
+// named profile.
+func ContextWithClientKey(ctx context.Context, profile string, key types.MaskedString) context.Context {
+	keys := maps.Clone(clientKeys(ctx))
+	if keys == nil {
+		keys = make(map[string]types.MaskedString)
+	}
+
+	keys[profile] = key
+
+	return context.WithValue(ctx, clientKey, keys)
+}
+
+// ClientKeyFromContext returns the Portkey API key that the client provided for the named profile, if any.
+func ClientKeyFromContext(ctx context.Context, profile string) (types.MaskedString, bool) {
+	key := clientKeys(ctx)[profile]
+
+	return key, key != ""
+}
+
+func clientKeys(ctx context.Context) map[string]types.MaskedString {
+	keys, _ := ctx.Value(clientKey).(map[string]types.MaskedString)
+
+	return keys
+}
+
+// Resolve returns the Portkey API key to call the profile with, per its configured mode. A client's key is only used
+// for the profile that it was provided for, as it belongs to a single org. Work that the server does on its own
+// behalf, outside of any client session, like refreshing the prompt catalog, always uses the server's key.
+func Resolve(ctx context.Context, portkey config.Portkey) (types.MaskedString, error) {
+	if !portkey.AcceptsClientKeys() || server.ClientSessionFromContext(ctx) == nil {
+		return portkey.APIKey, nil
+	}
+
+	if key, ok := ClientKeyFromContext(ctx, portkey.Profile); ok {
+		return key, nil
+	}
+
+	if portkey.APIKeyMode == config.APIKeyModeClientRequired {
+		return "", ErrClientKeyRequired
+	}
+
+	return portkey.APIKey, nil
+}
+
+// WithHTTPHeaders returns an HTTP context function that adds the key in each profile's request header, if any, to the
+// context. The headers are given by profile name.
+func WithHTTPHeaders(headers map[string]string) server.HTTPContextFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		for profile, header := range headers {
+			if key := r.Header.Get(header); key != "" {
+				ctx = ContextWithClientKey(ctx, profile, types.MaskedString(key))
+			}
+		}
+
+		return ctx
+	}
+}
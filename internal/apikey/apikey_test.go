@@ -0,0 +1,181 @@
+package apikey_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/apikey"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+const (
+	serverKey     = "server-key"
+	clientKey     = "client-key"
+	header        = "X-Portkey-Api-Key"
+	stagingKey    = "staging-key"
+	stagingHeader = "X-Portkey-Staging-Api-Key"
+)
+
+type testSession struct {
+	id string
+}
+
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) SessionID() string                                   { return s.id }
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	sessionCtx := server.NewMCPServer("test", "v1.0.0").WithContext(context.Background(), testSession{id: "session"})
+	clientCtx := apikey.ContextWithClientKey(sessionCtx, config.DefaultProfile, clientKey)
+	stagingCtx := apikey.ContextWithClientKey(sessionCtx, "staging", stagingKey)
+
+	tests := []struct {
+		name    string
+		mode    config.APIKeyMode
+		ctx     context.Context //nolint:containedctx
+		want    types.MaskedString
+		wantErr error
+	}{
+		{name: "server mode ignores client key", mode: config.APIKeyModeServer, ctx: clientCtx, want: serverKey},
+		{name: "client required with key", mode: config.APIKeyModeClientRequired, ctx: clientCtx, want: clientKey},
+		{
+			name:    "client required without key",
+			mode:    config.APIKeyModeClientRequired,
+			ctx:     sessionCtx,
+			wantErr: apikey.ErrClientKeyRequired,
+		},
+		{
+			name: "client required outside a session uses server key",
+			mode: config.APIKeyModeClientRequired,
+			ctx:  context.Background(),
+			want: serverKey,
+		},
+		{name: "client preferred with key", mode: config.APIKeyModeClientPreferred, ctx: clientCtx, want: clientKey},
+		{name: "client preferred without key", mode: config.APIKeyModeClientPreferred, ctx: sessionCtx, want: serverKey},
+		{
+			name:    "client required with another profile's key",
+			mode:    config.APIKeyModeClientRequired,
+			ctx:     stagingCtx,
+			wantErr: apikey.ErrClientKeyRequired,
+		},
+		{
+			name: "client preferred with another profile's key",
+			mode: config.APIKeyModeClientPreferred,
+			ctx:  stagingCtx,
+			want: serverKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			portkey := config.Portkey{ //nolint:exhaustruct
+				APIKey:     serverKey,
+				APIKeyMode: tt.mode,
+				Profile:    config.DefaultProfile,
+			}
+
+			got, err := apikey.Resolve(tt.ctx, portkey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected key %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithHTTPHeaders(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest("POST", "/mcp", nil)
+	req.Header.Set(header, clientKey)
+
+	ctx := apikey.WithHTTPHeaders(map[string]string{
+		config.DefaultProfile: header,
+		"staging":             stagingHeader,
+	})(context.Background(), req)
+
+	if key, ok := apikey.ClientKeyFromContext(ctx, config.DefaultProfile); !ok || key != clientKey {
+		t.Errorf("expected key from header, got %q", key)
+	}
+
+	if key, ok := apikey.ClientKeyFromContext(ctx, "staging"); ok {
+		t.Errorf("expected no key for a profile whose header wasn't sent, got %q", key)
+	}
+}
+
+func TestSessionsRememberInitializeKey(t *testing.T) {
+	t.Parallel()
+
+	hooks := &server.Hooks{}
+	sessions := apikey.NewSessions(map[string]string{config.DefaultProfile: header, "staging": stagingHeader})
+	sessions.AddHooks(hooks)
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0",
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(sessions.ToolMiddleware),
+	)
+
+	whoami := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, _ := apikey.ClientKeyFromContext(ctx, config.DefaultProfile)
+		staging, _ := apikey.ClientKeyFromContext(ctx, "staging")
+		unknown, _ := apikey.ClientKeyFromContext(ctx, "unknown")
+
+		return mcp.NewToolResultText(string(key) + "," + string(staging) + string(unknown)), nil
+	}
+
+	mcpServer.AddTool(mcp.NewTool("whoami"), whoami)
+
+	session := testSession{id: "session"}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("failed to register session: %v", err)
+	}
+
+	ctx := mcpServer.WithContext(context.Background(), session)
+
+	mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{`+
+		`"protocolVersion":"2025-06-18","clientInfo":{"name":"test","version":"v1.0.0"},`+
+		`"capabilities":{"experimental":{"portkey":{"api_key":"`+clientKey+`","profiles":{`+
+		`"staging":{"api_key":"`+stagingKey+`"},"unknown":{"api_key":"unknown-key"}}}}}}}`))
+
+	resp := mcpServer.HandleMessage(ctx, json.RawMessage(
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"whoami"}}`))
+
+	result, ok := resp.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a result, got %#v", resp)
+	}
+
+	callResult, ok := result.Result.(*mcp.CallToolResult)
+	if !ok || len(callResult.Content) == 0 {
+		t.Fatalf("unexpected tool result: %#v", result.Result)
+	}
+
+	if text := callResult.Content[0].(mcp.TextContent).Text; text != clientKey+","+stagingKey {
+		t.Errorf("expected the tool to see the keys of known profiles from initialize, got %q", text)
+	}
+
+	mcpServer.UnregisterSession(context.Background(), session.id)
+
+	resp = mcpServer.HandleMessage(ctx, json.RawMessage(
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"whoami"}}`))
+
+	result, _ = resp.(mcp.JSONRPCResponse)
+	if callResult, ok := result.Result.(*mcp.CallToolResult); ok && callResult.Content[0].(mcp.TextContent).Text != "," {
+		t.Error("expected the key to be forgotten once the session ends")
+	}
+}
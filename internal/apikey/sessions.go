@@ -0,0 +1,136 @@
+package apikey
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+const (
+	// ExperimentalCapability is the key under the client's experimental capabilities, in its initialize request, that
+	// it may provide its Portkey API keys in: its key for the default profile, and those for named profiles, e.g.
+	// {"experimental": {"portkey": {"api_key": "...", "profiles": {"staging": {"api_key": "..."}}}}}.
+	ExperimentalCapability = "portkey"
+
+	experimentalAPIKey   = "api_key"
+	experimentalProfiles = "profiles"
+)
+
+// Sessions remembers the Portkey API keys that each MCP session's client provided when it initialized, so that the
+// keys apply to the rest of the session even when later requests don't carry them, like over stdio.
+type Sessions struct {
+	headers map[string]string
+	keys    sync.Map // session ID -> map[string]types.MaskedString
+}
+
+// NewSessions returns a Sessions that takes keys for the profiles that accept them, and also looks for them in each
+// profile's header of HTTP initialize requests. The headers are given by profile name.
+func NewSessions(headers map[string]string) *Sessions {
+	return &Sessions{ //nolint:exhaustruct
+		headers: headers,
+	}
+}
+
+// AddHooks records each session's key once it has initialized, and forgets it when the session ends.
+func (s *Sessions) AddHooks(hooks *server.Hooks) {
+	hooks.AddAfterInitialize(func(ctx context.Context, _ any, req *mcp.InitializeRequest, _ *mcp.InitializeResult) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return
+		}
+
+		if keys := initializeKeys(req, s.headers); len(keys) > 0 {
+			s.keys.Store(session.SessionID(), keys)
+		}
+	})
+
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.keys.Delete(session.SessionID())
+	})
+}
+
+// WithContext adds the session's keys to the context, except for profiles that the request carried its own key for.
+func (s *Sessions) WithContext(ctx context.Context) context.Context {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return ctx
+	}
+
+	stored, ok := s.keys.Load(session.SessionID())
+	if !ok {
+		return ctx
+	}
+
+	keys, _ := stored.(map[string]types.MaskedString)
+
+	for profile, key := range keys {
+		if _, ok := ClientKeyFromContext(ctx, profile); !ok {
+			ctx = ContextWithClientKey(ctx, profile, key)
+		}
+	}
+
+	return ctx
+}
+
+// ToolMiddleware adds the session's key to the context of tool calls.
+func (s *Sessions) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(s.WithContext(ctx), request)
+	}
+}
+
+// PromptMiddleware adds the session's key to the context of prompt requests.
+func (s *Sessions) PromptMiddleware(next server.PromptHandlerFunc) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return next(s.WithContext(ctx), request)
+	}
+}
+
+// ResourceMiddleware adds the session's key to the context of resource reads.
+func (s *Sessions) ResourceMiddleware(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return next(s.WithContext(ctx), request)
+	}
+}
+
+// initializeKeys returns the keys, by profile, from the initialize request's experimental capabilities or, over
+// HTTP, its headers. Keys for profiles that don't accept them are ignored.
+func initializeKeys(req *mcp.InitializeRequest, headers map[string]string) map[string]types.MaskedString {
+	keys := make(map[string]types.MaskedString)
+
+	if portkey, ok := req.Params.Capabilities.Experimental[ExperimentalCapability].(map[string]any); ok {
+		if key, ok := portkey[experimentalAPIKey].(string); ok && key != "" {
+			keys[config.DefaultProfile] = types.MaskedString(key)
+		}
+
+		profiles, _ := portkey[experimentalProfiles].(map[string]any)
+
+		for profile, value := range profiles {
+			if profileKeys, ok := value.(map[string]any); ok {
+				if key, ok := profileKeys[experimentalAPIKey].(string); ok && key != "" {
+					keys[profile] = types.MaskedString(key)
+				}
+			}
+		}
+	}
+
+	for profile, header := range headers {
+		if _, ok := keys[profile]; !ok && req.Header != nil && req.Header.Get(header) != "" {
+			keys[profile] = types.MaskedString(req.Header.Get(header))
+		}
+	}
+
+	maps.DeleteFunc(keys, func(profile string, _ types.MaskedString) bool {
+		_, ok := headers[profile]
+
+		return !ok
+	})
+
+	return keys
+}
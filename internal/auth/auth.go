@@ -0,0 +1,224 @@
+// Package auth authenticates requests to the HTTP transports with static bearer tokens or HMAC-signed tokens.
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+var (
+	ErrMissingToken        = errors.New("missing bearer token")
+	ErrUnknownToken        = errors.New("unknown bearer token")
+	ErrMalformedTokensFile = errors.New("malformed bearer tokens file")
+	ErrInsufficientScope   = errors.New("token lacks a required scope")
+)
+
+// Identity is who a request was authenticated as.
+type Identity struct {
+	Principal string
+
+	// Scopes are those granted to an OAuth access token. They are nil for static and HMAC tokens, which aren't scoped.
+	Scopes []string
+}
+
+type bearerToken struct {
+	principal string
+	token     []byte
+}
+
+// Authenticator checks the bearer token of each request.
+type Authenticator struct {
+	tokens     []bearerToken
+	hmacSecret []byte
+	now        func() time.Time
+
+	// oauth is nil unless OAuth access tokens are accepted.
+	oauth    *jwtVerifier
+	oauthCfg config.OAuth
+}
+
+// New reads any token and secret files, and returns an Authenticator for them.
+func New(cfg config.Auth) (*Authenticator, error) {
+	authenticator := &Authenticator{ //nolint:exhaustruct
+		tokens:     make([]bearerToken, 0, len(cfg.BearerTokens)),
+		hmacSecret: []byte(cfg.HMACSecret),
+		now:        time.Now,
+		oauthCfg:   cfg.OAuth,
+	}
+
+	for principal, token := range cfg.BearerTokens {
+		authenticator.tokens = append(authenticator.tokens, bearerToken{principal: principal, token: []byte(token)})
+	}
+
+	if cfg.BearerTokensFile != "" {
+		tokens, err := readTokensFile(cfg.BearerTokensFile)
+		if err != nil {
+			return nil, err
+		}
+
+		authenticator.tokens = append(authenticator.tokens, tokens...)
+	}
+
+	if cfg.HMACSecretFile != "" {
+		secret, err := os.ReadFile(cfg.HMACSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hmac secret file: %w", err)
+		}
+
+		authenticator.hmacSecret = bytes.TrimSpace(secret)
+
+		if len(authenticator.hmacSecret) < config.MinHMACSecretLength {
+			return nil, config.ErrHMACSecretTooShort
+		}
+	}
+
+	if cfg.OAuth.Enabled() {
+		authenticator.oauth = &jwtVerifier{
+			cfg:  cfg.OAuth,
+			keys: newKeySet(cfg.OAuth),
+			now:  time.Now,
+		}
+	}
+
+	return authenticator, nil
+}
+
+// Authenticate returns who the request's bearer token belongs to.
+func (a *Authenticator) Authenticate(r *http.Request) (Identity, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return Identity{}, ErrMissingToken
+	}
+
+	// Every static token is compared, so the time taken doesn't reveal which one matched.
+	principal := ""
+
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), t.token) == 1 {
+			principal = t.principal
+		}
+	}
+
+	if principal != "" {
+		return Identity{Principal: principal, Scopes: nil}, nil
+	}
+
+	if len(a.hmacSecret) > 0 && strings.HasPrefix(token, hmacTokenVersion+".") {
+		principal, err := verifyHMACToken(a.hmacSecret, token, a.now())
+		if err != nil {
+			return Identity{}, err
+		}
+
+		return Identity{Principal: principal, Scopes: nil}, nil
+	}
+
+	if a.oauth != nil && looksLikeJWT(token) {
+		identity, err := a.oauth.verify(r.Context(), token)
+		if err != nil {
+			return Identity{}, err
+		}
+
+		if missing := missingScopes(identity.Scopes, a.oauthCfg.RequiredScopes); len(missing) > 0 {
+			return identity, fmt.Errorf("%w: %s", ErrInsufficientScope, strings.Join(missing, " "))
+		}
+
+		return identity, nil
+	}
+
+	return Identity{}, ErrUnknownToken
+}
+
+// Middleware rejects requests that aren't authenticated with 401, or 403 when their access token lacks a required
+// scope, and adds who they were authenticated as to the context of those that are.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := a.Authenticate(r)
+		if err != nil {
+			middleware.GetLogger(r.Context()).Info("rejected unauthenticated http request",
+				"http_req_method", r.Method,
+				"http_req_path", r.URL.Path,
+				"http_req_remote_addr", r.RemoteAddr,
+				"principal", identity.Principal,
+				"error", err)
+
+			a.writeChallenge(w, err)
+
+			return
+		}
+
+		ctx := middleware.ContextWithPrincipal(r.Context(), identity.Principal)
+
+		if identity.Scopes != nil {
+			ctx = middleware.ContextWithScopes(ctx, identity.Scopes)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// writeChallenge responds with a WWW-Authenticate challenge that tells the client how to authenticate, including where
+// to discover the authorization server when OAuth is enabled (RFC 6750 and RFC 9728).
+func (a *Authenticator) writeChallenge(w http.ResponseWriter, err error) {
+	params := []string{`realm="portkey-mcp-server"`}
+	status := http.StatusUnauthorized
+
+	if a.oauth != nil {
+		params = append(params, fmt.Sprintf("resource_metadata=%q", a.metadataURL()))
+
+		switch {
+		case errors.Is(err, ErrInsufficientScope):
+			status = http.StatusForbidden
+			params = append(params, `error="insufficient_scope"`,
+				fmt.Sprintf("scope=%q", strings.Join(a.oauthCfg.RequiredScopes, " ")))
+		case !errors.Is(err, ErrMissingToken):
+			params = append(params, `error="invalid_token"`)
+		}
+	}
+
+	w.Header().Set("WWW-Authenticate", "Bearer "+strings.Join(params, ", "))
+
+	http.Error(w, http.StatusText(status), status)
+}
+
+func readTokensFile(path string) ([]bearerToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bearer tokens file: %w", err)
+	}
+
+	var tokens []bearerToken
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		principal, token, ok := strings.Cut(line, ":")
+		principal, token = strings.TrimSpace(principal), strings.TrimSpace(token)
+
+		if !ok || principal == "" || token == "" {
+			return nil, fmt.Errorf("%w: line %d must be \"principal:token\"", ErrMalformedTokensFile, lineNum)
+		}
+
+		tokens = append(tokens, bearerToken{principal: principal, token: []byte(token)})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read bearer tokens file: %w", err)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,136 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+const hmacSecret = "0123456789abcdef0123456789abcdef"
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tokensFile := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(tokensFile, []byte("# comment\n\nbob: file-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write tokens file: %v", err)
+	}
+
+	authenticator, err := auth.New(config.Auth{
+		BearerTokens:     map[string]types.MaskedString{"alice": "static-token"},
+		BearerTokensFile: tokensFile,
+		HMACSecret:       hmacSecret,
+		HMACSecretFile:   "",
+	})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := middleware.GetPrincipal(r.Context())
+		_, _ = w.Write([]byte(principal))
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantPrincipal string
+	}{
+		{
+			name:          "static token",
+			authorization: "Bearer static-token",
+			wantStatus:    http.StatusOK,
+			wantPrincipal: "alice",
+		},
+		{
+			name:          "token from file",
+			authorization: "Bearer file-token",
+			wantStatus:    http.StatusOK,
+			wantPrincipal: "bob",
+		},
+		{
+			name:          "hmac token",
+			authorization: "Bearer " + auth.SignHMACToken([]byte(hmacSecret), "ci.pipeline", time.Now().Add(time.Hour)),
+			wantStatus:    http.StatusOK,
+			wantPrincipal: "ci.pipeline",
+		},
+		{
+			name:          "expired hmac token",
+			authorization: "Bearer " + auth.SignHMACToken([]byte(hmacSecret), "ci", time.Now().Add(-time.Second)),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name: "hmac token signed with another secret",
+			authorization: "Bearer " + auth.SignHMACToken(
+				[]byte(strings.Repeat("x", config.MinHMACSecretLength)), "ci", time.Now().Add(time.Hour)),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "unknown token",
+			authorization: "Bearer nope",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "missing token",
+			authorization: "",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "basic auth",
+			authorization: "Basic c3RhdGljLXRva2Vu",
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/message", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate header")
+			}
+
+			if tt.wantStatus == http.StatusOK && rec.Body.String() != tt.wantPrincipal {
+				t.Errorf("expected principal %q, got %q", tt.wantPrincipal, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewRejectsMalformedTokensFile(t *testing.T) {
+	t.Parallel()
+
+	tokensFile := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(tokensFile, []byte("just-a-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write tokens file: %v", err)
+	}
+
+	_, err := auth.New(config.Auth{ //nolint:exhaustruct
+		BearerTokensFile: tokensFile,
+	})
+	if err == nil {
+		t.Fatal("expected an error for a line without a principal")
+	}
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hmacTokenVersion prefixes signed tokens, so that the format can change without accepting old tokens by mistake.
+const hmacTokenVersion = "v1"
+
+var (
+	ErrMalformedHMACToken = errors.New("malformed hmac token")
+	ErrInvalidSignature   = errors.New("invalid hmac token signature")
+	ErrTokenExpired       = errors.New("token has expired")
+)
+
+// SignHMACToken returns a token that authenticates as principal until expiresAt. Its format is
+// "v1.<base64url principal>.<expiry unix seconds>.<base64url HMAC-SHA256 of everything before it>".
+func SignHMACToken(secret []byte, principal string, expiresAt time.Time) string {
+	payload := strings.Join([]string{
+		hmacTokenVersion,
+		base64.RawURLEncoding.EncodeToString([]byte(principal)),
+		strconv.FormatInt(expiresAt.Unix(), 10),
+	}, ".")
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(sign(secret, payload))
+}
+
+// verifyHMACToken checks the token's signature and expiry, and returns the principal it was signed for.
+func verifyHMACToken(secret []byte, token string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 || parts[0] != hmacTokenVersion { //nolint:mnd
+		return "", ErrMalformedHMACToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformedHMACToken, err)
+	}
+
+	if !hmac.Equal(signature, sign(secret, strings.Join(parts[:3], "."))) {
+		return "", ErrInvalidSignature
+	}
+
+	principal, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(principal) == 0 {
+		return "", ErrMalformedHMACToken
+	}
+
+	expiry, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformedHMACToken, err)
+	}
+
+	if !now.Before(time.Unix(expiry, 0)) {
+		return "", ErrTokenExpired
+	}
+
+	return string(principal), nil
+}
+
+func sign(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
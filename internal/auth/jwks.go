@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+const (
+	// jwksMinRefreshInterval limits how often a token with an unknown key ID can trigger a refresh, so that made-up key
+	// IDs can't be used to hammer the issuer.
+	jwksMinRefreshInterval = 30 * time.Second
+	jwksFetchTimeout       = 10 * time.Second
+	jwksMaxSize            = 1 << 20
+)
+
+var (
+	ErrUnknownKey          = errors.New("no key in jwks for token")
+	ErrUnexpectedJWKSCode  = errors.New("unexpected status code fetching jwks")
+	ErrUnsupportedKeyType  = errors.New("unsupported jwk key type")
+	ErrUnsupportedKeyCurve = errors.New("unsupported jwk curve")
+)
+
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Alg     string `json:"alg"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC and OKP
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+type publicKey struct {
+	key crypto.PublicKey
+
+	// alg is the algorithm the key is restricted to, if the JWK names one.
+	alg string
+}
+
+// keySet is the issuer's JWKS, loaded from a file or URL and cached for the configured TTL. It is reloaded by one
+// request at a time, while the others keep using the cached keys.
+type keySet struct {
+	cfg        config.OAuth
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+
+	// refreshing is closed once the reload in progress is done. It is nil while no reload is in progress.
+	refreshing chan struct{}
+}
+
+func newKeySet(cfg config.OAuth) *keySet {
+	return &keySet{ //nolint:exhaustruct
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: jwksFetchTimeout}, //nolint:exhaustruct
+	}
+}
+
+// key returns the key with the given ID. The JWKS is reloaded when the cache has expired, or when the key isn't in it,
+// which is how keys rotated in by the issuer are picked up. Only a key that isn't in the cache waits for the reload.
+func (s *keySet) key(ctx context.Context, kid string) (publicKey, error) {
+	keys, fetchedAt := s.cached()
+
+	if time.Since(fetchedAt) > s.cfg.JWKSCacheTTL {
+		done := s.refresh(ctx)
+
+		// Until the first load is done, there are no keys to use in the meantime.
+		if keys == nil {
+			if err := wait(ctx, done); err != nil {
+				return publicKey{}, err
+			}
+
+			keys, fetchedAt = s.cached()
+		}
+	}
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(fetchedAt) > jwksMinRefreshInterval {
+		if err := wait(ctx, s.refresh(ctx)); err != nil {
+			return publicKey{}, err
+		}
+
+		keys, _ = s.cached()
+
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+	}
+
+	return publicKey{}, fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+}
+
+func (s *keySet) cached() (map[string]publicKey, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keys, s.fetchedAt
+}
+
+// refresh starts reloading the JWKS, unless a reload is in progress already, and returns a channel that is closed once
+// it is done. If the reload fails, the keys loaded previously are kept, so that an issuer outage doesn't reject tokens
+// that were valid a moment ago.
+func (s *keySet) refresh(ctx context.Context) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refreshing != nil {
+		return s.refreshing
+	}
+
+	done := make(chan struct{})
+	s.refreshing = done
+
+	// Loading isn't cut short by the request that happened to trigger it, since its result is shared.
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer close(done)
+
+		keys, err := s.load(ctx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if err != nil {
+			slog.Error("failed to load oauth jwks, using previously loaded keys", "keys", len(s.keys), "error", err)
+		} else {
+			s.keys = keys
+		}
+
+		// Failures count as fetches too, so that a broken issuer is retried at most every jwksMinRefreshInterval.
+		s.fetchedAt = time.Now()
+		s.refreshing = nil
+	}()
+
+	return done
+}
+
+// wait waits for a reload of the JWKS to be done, or for the request to be canceled.
+func wait(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("gave up waiting for oauth jwks: %w", ctx.Err())
+	}
+}
+
+func (s *keySet) load(ctx context.Context) (map[string]publicKey, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(jwks.Keys))
+
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			slog.Warn("skipping unusable key in oauth jwks", "kid", k.KeyID, "error", err)
+
+			continue
+		}
+
+		keys[k.KeyID] = publicKey{key: key, alg: k.Alg}
+	}
+
+	return keys, nil
+}
+
+func (s *keySet) read(ctx context.Context) ([]byte, error) {
+	if s.cfg.JWKSFile != "" {
+		data, err := os.ReadFile(s.cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwks request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedJWKSCode, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks response: %w", err)
+	}
+
+	return data, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+
+	case "EC":
+		curve, err := ecCurve(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec x coordinate: %w", err)
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec y coordinate: %w", err)
+		}
+
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec key: %w", err)
+		}
+
+		return key, nil
+
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyCurve, k.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid ed25519 key", ErrUnsupportedKeyType)
+		}
+
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, k.KeyType)
+	}
+}
+
+func ecCurve(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyCurve, name)
+	}
+}
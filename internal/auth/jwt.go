@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+var (
+	ErrMalformedJWT        = errors.New("malformed jwt")
+	ErrUnsupportedAlg      = errors.New("unsupported jwt signing algorithm")
+	ErrInvalidJWTSignature = errors.New("invalid jwt signature")
+	ErrInvalidIssuer       = errors.New("jwt issued by an unexpected issuer")
+	ErrInvalidAudience     = errors.New("jwt not issued for this resource")
+	ErrTokenNotYetValid    = errors.New("token is not valid yet")
+	ErrMissingSubject      = errors.New("jwt has no subject")
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	ClientID  string   `json:"client_id"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+
+	// Scope is the space-separated form of RFC 9068; Scp is the array form that some issuers use instead.
+	Scope string   `json:"scope"`
+	Scp   []string `json:"scp"`
+}
+
+// audience is a JWT "aud" claim, which may be a single string or an array of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("aud must be a string or an array of strings: %w", err)
+	}
+
+	*a = multiple
+
+	return nil
+}
+
+// jwtVerifier verifies OAuth access tokens issued as JWTs.
+type jwtVerifier struct {
+	cfg  config.OAuth
+	keys *keySet
+	now  func() time.Time
+}
+
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2 //nolint:mnd
+}
+
+// verify checks the token's signature, issuer, audience and validity period, and returns who it was issued to.
+func (v *jwtVerifier) verify(ctx context.Context, token string) (Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return Identity{}, ErrMalformedJWT
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Identity{}, err
+	}
+
+	key, err := v.keys.key(ctx, header.Kid)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// The algorithm in the header is attacker-controlled, so it must agree with the key's own restriction, if any.
+	if key.alg != "" && key.alg != header.Alg {
+		return Identity{}, fmt.Errorf("%w: %s for a %s key", ErrUnsupportedAlg, header.Alg, key.alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrMalformedJWT, err)
+	}
+
+	if err := verifySignature(header.Alg, key.key, parts[0]+"."+parts[1], signature); err != nil {
+		return Identity{}, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, err
+	}
+
+	return v.checkClaims(claims)
+}
+
+func (v *jwtVerifier) checkClaims(claims jwtClaims) (Identity, error) {
+	if claims.Issuer != v.cfg.Issuer {
+		return Identity{}, fmt.Errorf("%w: %q", ErrInvalidIssuer, claims.Issuer)
+	}
+
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(v.cfg.AcceptedAudiences(), aud)
+	}) {
+		return Identity{}, fmt.Errorf("%w: %q", ErrInvalidAudience, claims.Audience)
+	}
+
+	now := v.now()
+
+	if claims.ExpiresAt == nil || !now.Before(numericDate(*claims.ExpiresAt).Add(v.cfg.ClockSkew)) {
+		return Identity{}, ErrTokenExpired
+	}
+
+	if claims.NotBefore != nil && now.Add(v.cfg.ClockSkew).Before(numericDate(*claims.NotBefore)) {
+		return Identity{}, ErrTokenNotYetValid
+	}
+
+	principal := claims.Subject
+	if principal == "" {
+		principal = claims.ClientID
+	}
+
+	if principal == "" {
+		return Identity{}, ErrMissingSubject
+	}
+
+	scopes := strings.Fields(claims.Scope)
+	if len(scopes) == 0 {
+		scopes = claims.Scp
+	}
+
+	// A token without scopes is still scoped: it may only use what needs no scope.
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return Identity{Principal: principal, Scopes: scopes}, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	hash, err := algHash(alg)
+	if err != nil {
+		return err
+	}
+
+	var digest []byte
+
+	if hash != 0 {
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		digest = h.Sum(nil)
+	}
+
+	valid := false
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			valid = rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil
+		case "PS":
+			valid = rsa.VerifyPSS(k, hash, digest, signature, nil) == nil
+		}
+	case *ecdsa.PublicKey:
+		// JWS encodes ECDSA signatures as the fixed-size concatenation of r and s, rather than ASN.1.
+		size := (k.Curve.Params().BitSize + 7) / 8 //nolint:mnd
+		if k.Curve == esCurve(alg) && len(signature) == 2*size {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			valid = ecdsa.Verify(k, digest, r, s)
+		}
+	case ed25519.PublicKey:
+		valid = alg == "EdDSA" && ed25519.Verify(k, []byte(signingInput), signature)
+	}
+
+	if !valid {
+		return ErrInvalidJWTSignature
+	}
+
+	return nil
+}
+
+// esCurve returns the curve that an ES* alg signs with, or nil for other algs. A key on another curve can't be used
+// with the alg, even if the signature would verify.
+func esCurve(alg string) elliptic.Curve {
+	switch alg {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
+	case "ES512":
+		return elliptic.P521()
+	default:
+		return nil
+	}
+}
+
+// algHash returns the hash that alg signs with. EdDSA signs the message itself, so it has none.
+func algHash(alg string) (crypto.Hash, error) {
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		return crypto.SHA256, nil
+	case "RS384", "PS384", "ES384":
+		return crypto.SHA384, nil
+	case "RS512", "PS512", "ES512":
+		return crypto.SHA512, nil
+	case "EdDSA":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedJWT, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedJWT, err)
+	}
+
+	return nil
+}
+
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
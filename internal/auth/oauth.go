@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+// HandleMetadata serves the OAuth protected resource metadata (RFC 9728) on mux, when OAuth is enabled. It isn't
+// authenticated, since it is how clients discover where to get a token.
+func (a *Authenticator) HandleMetadata(mux *http.ServeMux) {
+	if a.oauth == nil {
+		return
+	}
+
+	handler := server.NewProtectedResourceMetadataHandler(server.ProtectedResourceMetadataConfig{ //nolint:exhaustruct
+		Resource:               a.oauthCfg.Resource,
+		AuthorizationServers:   []string{a.oauthCfg.Issuer},
+		ScopesSupported:        a.oauthCfg.ScopesSupported(),
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           "Portkey MCP Server",
+	})
+
+	// Clients look for the metadata at the path derived from the resource first, and then at the root.
+	mux.Handle(server.ProtectedResourceMetadataPath(a.oauthCfg.Resource), handler)
+
+	if server.ProtectedResourceMetadataPath(a.oauthCfg.Resource) != server.WellKnownProtectedResourcePath {
+		mux.Handle(server.WellKnownProtectedResourcePath, handler)
+	}
+}
+
+func (a *Authenticator) metadataURL() string {
+	resource, err := url.Parse(a.oauthCfg.Resource)
+	if err != nil {
+		return server.WellKnownProtectedResourcePath
+	}
+
+	metadata := url.URL{ //nolint:exhaustruct
+		Scheme: resource.Scheme,
+		Host:   resource.Host,
+		Path:   server.ProtectedResourceMetadataPath(a.oauthCfg.Resource),
+	}
+
+	return metadata.String()
+}
+
+// ToolFilter hides the tools that the request's access token lacks the scope for, per toolScopes. mcp-go applies it to
+// tools/call as well as tools/list, so a hidden tool can't be called either. Requests that weren't authenticated with
+// an access token, like those over stdio, see every tool.
+func ToolFilter(toolScopes map[string]string) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		scopes, ok := middleware.GetScopes(ctx)
+		if !ok {
+			return tools
+		}
+
+		return slices.DeleteFunc(slices.Clone(tools), func(tool mcp.Tool) bool {
+			scope, ok := toolScopes[tool.Name]
+			if !ok {
+				scope = toolScopes[config.AnyTool]
+			}
+
+			return scope != "" && !slices.Contains(scopes, scope)
+		})
+	}
+}
+
+// missingScopes returns the required scopes that weren't granted.
+func missingScopes(granted, required []string) []string {
+	var missing []string
+
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
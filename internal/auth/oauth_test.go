@@ -0,0 +1,508 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testResource = "https://mcp.example.com/mcp"
+)
+
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ec    *ecdsa.PrivateKey
+	other *rsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ec key: %v", err)
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey, other: otherKey}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// jwks returns the public half of the RSA and EC keys, as a local stand-in for the issuer's JWKS.
+func (k testKeys) jwks(t *testing.T) []byte {
+	t.Helper()
+
+	ecPublic, err := k.ec.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("failed to encode ec key: %v", err)
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-1",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   b64(k.rsa.N.Bytes()),
+				"e":   b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-1",
+				"crv": "P-256",
+				"x":   b64(ecPublic[1:33]),
+				"y":   b64(ecPublic[33:]),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal jwks: %v", err)
+	}
+
+	return data
+}
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "at+jwt"})
+	if err != nil {
+		t.Fatalf("failed to marshal header: %v", err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+
+	signingInput := b64(header) + "." + b64(payload)
+
+	// The signature is made with the hash that alg names, whatever the key.
+	hash := crypto.SHA256
+
+	switch alg[2:] {
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	}
+
+	h := hash.New()
+	h.Write([]byte(signingInput))
+	digest := h.Sum(nil)
+
+	var signature []byte
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	if err != nil {
+		t.Fatalf("failed to sign jwt: %v", err)
+	}
+
+	return signingInput + "." + b64(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"iss":   testIssuer,
+		"sub":   "user-123",
+		"aud":   []string{"other-api", testResource},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nbf":   time.Now().Add(-time.Minute).Unix(),
+		"scope": "portkey.read portkey.write",
+	}
+}
+
+// clientCredentialsClaims are those of a token issued to a client rather than a user, with array-form scopes.
+func clientCredentialsClaims() map[string]any {
+	claims := validClaims()
+	delete(claims, "sub")
+	delete(claims, "scope")
+	claims["client_id"] = "ci-client"
+	claims["scp"] = []string{"portkey.read"}
+
+	return claims
+}
+
+func withClaim(key string, value any) map[string]any {
+	claims := validClaims()
+	claims[key] = value
+
+	return claims
+}
+
+func newOAuthConfig(jwksURL string) config.Auth {
+	return config.Auth{ //nolint:exhaustruct
+		OAuth: config.OAuth{
+			Issuer:         testIssuer,
+			Resource:       testResource,
+			Audiences:      nil,
+			JWKSURL:        jwksURL,
+			JWKSFile:       "",
+			JWKSCacheTTL:   time.Hour,
+			RequiredScopes: []string{"portkey.read"},
+			ToolScopes:     map[string]string{"prompt_create": "portkey.write", config.AnyTool: "portkey.read"},
+			ClockSkew:      time.Minute,
+		},
+	}
+}
+
+func TestOAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+	jwks := keys.jwks(t)
+
+	var jwksFetches atomic.Int32
+
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		jwksFetches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks)
+	}))
+	t.Cleanup(jwksServer.Close)
+
+	authenticator, err := auth.New(newOAuthConfig(jwksServer.URL))
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := middleware.GetPrincipal(r.Context())
+		scopes, _ := middleware.GetScopes(r.Context())
+		_, _ = w.Write([]byte(principal + "|" + strings.Join(scopes, " ")))
+	}))
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+		wantBody   string
+		wantError  string
+	}{
+		{
+			name:       "rs256 token",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, validClaims()),
+			wantStatus: http.StatusOK,
+			wantBody:   "user-123|portkey.read portkey.write",
+		},
+		{
+			name:       "es256 token with scp claim and client_id",
+			token:      signJWT(t, "ES256", "ec-1", keys.ec, clientCredentialsClaims()),
+			wantStatus: http.StatusOK,
+			wantBody:   "ci-client|portkey.read",
+		},
+		{
+			name:       "wrong audience",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, withClaim("aud", "https://elsewhere.example.com")),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "wrong issuer",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, withClaim("iss", "https://evil.example.com")),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "expired",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, withClaim("exp", time.Now().Add(-time.Hour).Unix())),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "not yet valid",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, withClaim("nbf", time.Now().Add(time.Hour).Unix())),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "signed by another key",
+			token:      signJWT(t, "RS256", "rsa-1", keys.other, validClaims()),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "algorithm the key isn't for",
+			token:      signJWT(t, "RS512", "rsa-1", keys.rsa, validClaims()),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "es512 with a p-256 key",
+			token:      signJWT(t, "ES512", "ec-1", keys.ec, validClaims()),
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid_token",
+		},
+		{
+			name:       "missing required scope",
+			token:      signJWT(t, "RS256", "rsa-1", keys.rsa, withClaim("scope", "portkey.write")),
+			wantStatus: http.StatusForbidden,
+			wantError:  "insufficient_scope",
+		},
+		{
+			name:       "no token",
+			token:      "",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, rec.Body.String())
+			}
+
+			if rec.Code == http.StatusOK {
+				return
+			}
+
+			challenge := rec.Header().Get("WWW-Authenticate")
+
+			wantMetadata := `resource_metadata="https://mcp.example.com/.well-known/oauth-protected-resource/mcp"`
+			if !strings.Contains(challenge, wantMetadata) {
+				t.Errorf("expected challenge to point at the resource metadata, got %q", challenge)
+			}
+
+			if tt.wantError != "" && !strings.Contains(challenge, `error="`+tt.wantError+`"`) {
+				t.Errorf("expected challenge error %q, got %q", tt.wantError, challenge)
+			}
+		})
+	}
+
+	t.Cleanup(func() {
+		if fetches := jwksFetches.Load(); fetches != 1 {
+			t.Errorf("expected the jwks to be fetched once and cached, got %d fetches", fetches)
+		}
+	})
+}
+
+// TestOAuthJWKSRefreshDoesNotBlock makes sure that tokens signed with a cached key are still accepted while a slow
+// issuer is being asked for its JWKS again.
+func TestOAuthJWKSRefreshDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+	jwks := keys.jwks(t)
+
+	var slow atomic.Bool
+
+	release := make(chan struct{})
+
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if slow.Load() {
+			<-release
+		}
+
+		_, _ = w.Write(jwks)
+	}))
+	t.Cleanup(jwksServer.Close)
+	t.Cleanup(func() { close(release) })
+
+	cfg := newOAuthConfig(jwksServer.URL)
+	cfg.OAuth.JWKSCacheTTL = 10 * time.Millisecond
+
+	authenticator, err := auth.New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	authenticate := func() error {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.Header.Set("Authorization", "Bearer "+signJWT(t, "ES256", "ec-1", keys.ec, validClaims()))
+
+		_, err := authenticator.Authenticate(req)
+
+		return err
+	}
+
+	if err := authenticate(); err != nil {
+		t.Fatalf("expected token to be accepted: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	slow.Store(true)
+
+	for range 2 {
+		done := make(chan error, 1)
+
+		go func() { done <- authenticate() }()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("expected token to be accepted with the cached key: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("authentication waited for the jwks to be fetched again")
+		}
+	}
+}
+
+func TestOAuthJWKSFile(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksFile, keys.jwks(t), 0o600); err != nil {
+		t.Fatalf("failed to write jwks file: %v", err)
+	}
+
+	cfg := newOAuthConfig("")
+	cfg.OAuth.JWKSFile = jwksFile
+
+	authenticator, err := auth.New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+signJWT(t, "ES256", "ec-1", keys.ec, validClaims()))
+
+	identity, err := authenticator.Authenticate(req)
+	if err != nil {
+		t.Fatalf("expected token to be accepted: %v", err)
+	}
+
+	if identity.Principal != "user-123" {
+		t.Errorf("expected principal user-123, got %q", identity.Principal)
+	}
+}
+
+func TestProtectedResourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.New(newOAuthConfig("https://issuer.example.com/jwks"))
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	authenticator.HandleMetadata(mux)
+
+	for _, path := range []string{"/.well-known/oauth-protected-resource/mcp", "/.well-known/oauth-protected-resource"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200 for %s, got %d", path, rec.Code)
+		}
+
+		var metadata struct {
+			Resource             string   `json:"resource"`
+			AuthorizationServers []string `json:"authorization_servers"`
+			ScopesSupported      []string `json:"scopes_supported"`
+		}
+
+		if err := json.Unmarshal(rec.Body.Bytes(), &metadata); err != nil {
+			t.Fatalf("failed to parse metadata: %v", err)
+		}
+
+		if metadata.Resource != testResource {
+			t.Errorf("expected resource %q, got %q", testResource, metadata.Resource)
+		}
+
+		if !slices.Equal(metadata.AuthorizationServers, []string{testIssuer}) {
+			t.Errorf("expected authorization servers [%s], got %v", testIssuer, metadata.AuthorizationServers)
+		}
+
+		if !slices.Equal(metadata.ScopesSupported, []string{"portkey.read", "portkey.write"}) {
+			t.Errorf("unexpected scopes supported: %v", metadata.ScopesSupported)
+		}
+	}
+}
+
+func TestToolFilter(t *testing.T) {
+	t.Parallel()
+
+	filter := auth.ToolFilter(map[string]string{"prompt_create": "portkey.write", config.AnyTool: "portkey.read"})
+
+	allTools := []mcp.Tool{
+		mcp.NewTool("prompt_create"),
+		mcp.NewTool("prompts_list"),
+	}
+
+	toolNames := func(ctx context.Context) []string {
+		var names []string
+		for _, tool := range filter(ctx, allTools) {
+			names = append(names, tool.Name)
+		}
+
+		return names
+	}
+
+	if got := toolNames(context.Background()); len(got) != 2 {
+		t.Errorf("expected unscoped requests to see every tool, got %v", got)
+	}
+
+	readOnly := middleware.ContextWithScopes(context.Background(), []string{"portkey.read"})
+	if got := toolNames(readOnly); !slices.Equal(got, []string{"prompts_list"}) {
+		t.Errorf("expected read-only token to see only prompts_list, got %v", got)
+	}
+
+	noScopes := middleware.ContextWithScopes(context.Background(), []string{})
+	if got := toolNames(noScopes); len(got) != 0 {
+		t.Errorf("expected token without scopes to see no tools, got %v", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Package breaker fails requests to Portkey fast while it is down, with a circuit breaker per upstream host, or per
+// endpoint of each host.
+package breaker
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets every request through.
+	StateClosed State = iota
+
+	// StateOpen fails every request, until the open duration has passed.
+	StateOpen
+
+	// StateHalfOpen lets a few probes through, whose outcome closes or opens the breaker again.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// outcome is what a request tells a breaker about its upstream.
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+
+	// outcomeIgnored is a request that says nothing about the upstream, like one that the caller canceled.
+	outcomeIgnored
+)
+
+// breaker is the circuit breaker of a single host or endpoint.
+type breaker struct {
+	key  string
+	host string
+	cfg  config.Breaker
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// allow reports whether a request may be made now, and whether it is a probe of a half-open breaker. Every allowed
+// request must be followed by a call to record.
+func (b *breaker) allow(now time.Time) (bool, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.cfg.OpenDuration {
+		b.state = StateHalfOpen
+		b.probes = 0
+
+		slog.Info("portkey circuit breaker half-open, probing", "breaker", b.key)
+	}
+
+	switch b.state {
+	case StateOpen:
+		return false, false
+	case StateHalfOpen:
+		if b.probes >= b.cfg.HalfOpenProbes {
+			return false, false
+		}
+
+		b.probes++
+
+		return true, true
+	case StateClosed:
+	}
+
+	return true, false
+}
+
+// record updates the breaker with the outcome of a request that allow let through.
+func (b *breaker) record(now time.Time, probe bool, result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probes--
+	}
+
+	switch result {
+	case outcomeIgnored:
+	case outcomeSuccess:
+		b.failures = 0
+
+		if b.state == StateHalfOpen && probe {
+			b.state = StateClosed
+
+			slog.Info("portkey circuit breaker closed", "breaker", b.key)
+		}
+	case outcomeFailure:
+		b.failures++
+
+		switch {
+		case b.state == StateHalfOpen && probe:
+			b.open(now, "probe failed")
+		case b.state == StateClosed && b.failures >= b.cfg.FailureThreshold:
+			b.open(now, "too many consecutive failures")
+		}
+	}
+}
+
+func (b *breaker) open(now time.Time, reason string) {
+	b.state = StateOpen
+	b.openedAt = now
+
+	slog.Warn("portkey circuit breaker opened",
+		"breaker", b.key,
+		"reason", reason,
+		"consecutive_failures", b.failures,
+		"open_duration", b.cfg.OpenDuration,
+	)
+}
+
+// current returns the state that the next request would find the breaker in.
+func (b *breaker) current(now time.Time) State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.cfg.OpenDuration {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
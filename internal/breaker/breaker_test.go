@@ -0,0 +1,138 @@
+package breaker_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+const openDuration = 50 * time.Millisecond
+
+// newPortkey returns a server that responds with the status in *status, and counts the requests it receives.
+func newPortkey(t *testing.T, status *atomic.Int32, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(portkey.Close)
+
+	return portkey
+}
+
+func newClient(breakers *breaker.Breakers, perEndpoint bool) *http.Client {
+	cfg := config.Breaker{
+		FailureThreshold: 2,
+		OpenDuration:     openDuration,
+		HalfOpenProbes:   1,
+		PerEndpoint:      perEndpoint,
+	}
+
+	return &http.Client{Transport: breakers.Transport(cfg, nil)} //nolint:exhaustruct
+}
+
+func get(t *testing.T, client *http.Client, url string) error {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	var status, calls atomic.Int32
+
+	status.Store(http.StatusBadGateway)
+
+	portkey := newPortkey(t, &status, &calls)
+	breakers := breaker.New()
+	client := newClient(breakers, false)
+	host := strings.TrimPrefix(portkey.URL, "http://")
+
+	for range 2 {
+		if err := get(t, client, portkey.URL+"/v1/prompts"); err != nil {
+			t.Fatalf("expected portkey's response while the breaker is closed, got %v", err)
+		}
+	}
+
+	if err := get(t, client, portkey.URL+"/v1/prompts"); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected %v once the threshold is reached, got %v", breaker.ErrOpen, err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected the open breaker not to call portkey, got %d calls", calls.Load())
+	}
+
+	if open := breakers.Open(host); len(open) != 1 || open[0] != host {
+		t.Errorf("expected the breaker of %s to be open, got %v", host, open)
+	}
+
+	// A failed probe opens the breaker again.
+	time.Sleep(openDuration)
+
+	if err := get(t, client, portkey.URL+"/v1/prompts"); err != nil {
+		t.Fatalf("expected the probe to reach portkey, got %v", err)
+	}
+
+	if err := get(t, client, portkey.URL+"/v1/prompts"); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected %v after a failed probe, got %v", breaker.ErrOpen, err)
+	}
+
+	// A successful probe closes it.
+	status.Store(http.StatusOK)
+	time.Sleep(openDuration)
+
+	for range 3 {
+		if err := get(t, client, portkey.URL+"/v1/prompts"); err != nil {
+			t.Fatalf("expected the breaker to close after a successful probe, got %v", err)
+		}
+	}
+
+	if open := breakers.Open(host); len(open) != 0 {
+		t.Errorf("expected no open breakers, got %v", open)
+	}
+}
+
+func TestBreakerPerEndpoint(t *testing.T) {
+	t.Parallel()
+
+	var status, calls atomic.Int32
+
+	status.Store(http.StatusServiceUnavailable)
+
+	portkey := newPortkey(t, &status, &calls)
+	breakers := breaker.New()
+	client := newClient(breakers, true)
+
+	for range 3 {
+		_ = get(t, client, portkey.URL+"/v1/logs/exports")
+	}
+
+	if err := get(t, client, portkey.URL+"/v1/logs/exports"); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected %v for the failing endpoint, got %v", breaker.ErrOpen, err)
+	}
+
+	if err := get(t, client, portkey.URL+"/v1/prompts/my-prompt/render"); err != nil {
+		t.Errorf("expected other endpoints to keep calling portkey, got %v", err)
+	}
+
+	want := strings.TrimPrefix(portkey.URL, "http://") + " /logs/exports"
+	if open := breakers.Open(strings.TrimPrefix(portkey.URL, "http://")); len(open) != 1 || open[0] != want {
+		t.Errorf("expected only %q to be open, got %v", want, open)
+	}
+}
@@ -0,0 +1,116 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+)
+
+var ErrOpen = errors.New("portkey temporarily unavailable")
+
+// Breakers holds the circuit breakers of every upstream host, or endpoint, shared by all the HTTP clients that call
+// it. Clients whose configs differ, like those of profiles on the same host, share the breaker that the first of them
+// made.
+type Breakers struct {
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+func New() *Breakers {
+	return &Breakers{ //nolint:exhaustruct
+		breakers: make(map[string]*breaker),
+	}
+}
+
+// Transport returns an http.RoundTripper that sends requests through next, which is http.DefaultTransport if nil,
+// unless their breaker is open, in which case they fail with ErrOpen. If the config disables breakers, next is
+// returned as-is.
+func (b *Breakers) Transport(cfg config.Breaker, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if !cfg.Enabled() {
+		return next
+	}
+
+	return &transport{breakers: b, cfg: cfg, next: next}
+}
+
+// Open returns the keys of the open breakers of the host, which are the host itself, or its endpoints.
+func (b *Breakers) Open(host string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	var open []string
+
+	for _, br := range b.breakers {
+		if br.host == host && br.current(now) == StateOpen {
+			open = append(open, br.key)
+		}
+	}
+
+	slices.Sort(open)
+
+	return open
+}
+
+func (b *Breakers) get(host, key string, cfg config.Breaker) *breaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.breakers[key]
+	if !ok {
+		br = &breaker{key: key, host: host, cfg: cfg} //nolint:exhaustruct
+		b.breakers[key] = br
+	}
+
+	return br
+}
+
+type transport struct {
+	breakers *Breakers
+	cfg      config.Breaker
+	next     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.URL.Host
+	if t.cfg.PerEndpoint {
+		key += " " + metrics.EndpointTemplate(req.URL.EscapedPath())
+	}
+
+	br := t.breakers.get(req.URL.Host, key, t.cfg)
+
+	allowed, probe := br.allow(time.Now())
+	if !allowed {
+		return nil, fmt.Errorf("%w: circuit breaker for %s is open", ErrOpen, key)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	br.record(time.Now(), probe, outcomeOf(req, resp, err))
+
+	return resp, err //nolint:wrapcheck
+}
+
+// outcomeOf decides whether a request failed because of its upstream: it couldn't be sent, timed out, or got a 5xx
+// response. Requests that the caller canceled say nothing about the upstream.
+func outcomeOf(req *http.Request, resp *http.Response, err error) outcome {
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		return outcomeIgnored
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
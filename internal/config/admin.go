@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidReadinessCacheTTL = errors.New("readiness cache ttl must be positive")
+	ErrInvalidReadinessTimeout  = errors.New("readiness timeout must be positive")
+)
+
+// Admin configures the endpoints that load balancers and orchestrators probe: /healthz, /readyz and /version. They
+// are served by the HTTP transports, outside of authentication, and on Address if it is set.
+type Admin struct {
+	// Address serves the admin endpoints on a listener of their own, e.g. for a stdio server, or to keep them off the
+	// public listener, along with the Prometheus metrics at /metrics. It is disabled when empty.
+	Address string `envconfig:"ADDRESS"`
+
+	// ReadinessCacheTTL is how long the result of the readiness check's Portkey call is reused for, so that frequent
+	// probes don't each call Portkey.
+	ReadinessCacheTTL time.Duration `default:"30s" envconfig:"READINESS_CACHE_TTL"`
+
+	// ReadinessTimeout bounds the readiness check's Portkey call.
+	ReadinessTimeout time.Duration `default:"5s" envconfig:"READINESS_TIMEOUT"`
+}
+
+func (cfg *Admin) Validate() error {
+	if cfg.ReadinessCacheTTL <= 0 {
+		return ErrInvalidReadinessCacheTTL
+	}
+
+	if cfg.ReadinessTimeout <= 0 {
+		return ErrInvalidReadinessTimeout
+	}
+
+	return nil
+}
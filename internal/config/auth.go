@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+var (
+	ErrAuthFileNotExist   = errors.New("auth file does not exist")
+	ErrEmptyBearerToken   = errors.New("bearer token must not be empty")
+	ErrEmptyPrincipal     = errors.New("principal name must not be empty")
+	ErrHMACSecretConflict = errors.New("only one of hmac secret and hmac secret file may be set")
+	ErrHMACSecretTooShort = errors.New("hmac secret must be at least 32 bytes")
+)
+
+// MinHMACSecretLength is the shortest HMAC secret that is accepted, in bytes.
+const MinHMACSecretLength = 32
+
+// Auth configures authentication for the HTTP transports. Requests are authenticated when any bearer tokens, an HMAC
+// secret or an OAuth issuer are configured, and rejected with 401 when they don't carry a valid token.
+type Auth struct {
+	// BearerTokens maps principal names to static bearer tokens, e.g. "ci:token-1,alice:token-2".
+	BearerTokens map[string]types.MaskedString `envconfig:"BEARER_TOKENS"`
+
+	// BearerTokensFile holds more bearer tokens, one "principal:token" per line. Blank lines and lines starting with
+	// '#' are ignored.
+	BearerTokensFile string `envconfig:"BEARER_TOKENS_FILE"`
+
+	// HMACSecret verifies signed tokens, which carry their own principal and expiry.
+	HMACSecret     types.MaskedString `envconfig:"HMAC_SECRET"`
+	HMACSecretFile string             `envconfig:"HMAC_SECRET_FILE"`
+
+	OAuth OAuth `envconfig:"OAUTH"`
+}
+
+// Enabled reports whether requests to the HTTP transports must be authenticated.
+func (cfg *Auth) Enabled() bool {
+	return len(cfg.BearerTokens) > 0 || cfg.BearerTokensFile != "" ||
+		cfg.HMACSecret != "" || cfg.HMACSecretFile != "" ||
+		cfg.OAuth.Enabled()
+}
+
+func (cfg *Auth) Validate() error {
+	for principal, token := range cfg.BearerTokens {
+		if principal == "" {
+			return ErrEmptyPrincipal
+		}
+
+		if token == "" {
+			return fmt.Errorf("%w: %s", ErrEmptyBearerToken, principal)
+		}
+	}
+
+	if cfg.HMACSecret != "" && cfg.HMACSecretFile != "" {
+		return ErrHMACSecretConflict
+	}
+
+	if cfg.HMACSecret != "" && len(cfg.HMACSecret) < MinHMACSecretLength {
+		return ErrHMACSecretTooShort
+	}
+
+	for _, path := range []string{cfg.BearerTokensFile, cfg.HMACSecretFile} {
+		if path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrAuthFileNotExist, path)
+		}
+	}
+
+	if err := cfg.OAuth.Validate(); err != nil {
+		return fmt.Errorf("error validating oauth config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFailureThreshold = errors.New("failure threshold must not be negative")
+	ErrInvalidOpenDuration     = errors.New("open duration must be positive")
+	ErrInvalidHalfOpenProbes   = errors.New("half-open probes must be at least 1")
+)
+
+// Breaker configures the circuit breakers that fail requests to Portkey fast while it is down, rather than letting
+// each of them wait for the client timeout.
+type Breaker struct {
+	// FailureThreshold is how many consecutive failures, i.e. connection errors, timeouts and 5xx responses, open a
+	// breaker. 0 disables the breakers.
+	FailureThreshold int `default:"5" envconfig:"FAILURE_THRESHOLD" json:"failure_threshold"`
+
+	// OpenDuration is how long an open breaker fails requests for, before letting probes through.
+	OpenDuration time.Duration `default:"30s" envconfig:"OPEN_DURATION" json:"open_duration"`
+
+	// HalfOpenProbes is how many requests are let through at once after OpenDuration. The breaker closes when one of
+	// them succeeds, and opens again when one fails.
+	HalfOpenProbes int `default:"1" envconfig:"HALF_OPEN_PROBES" json:"half_open_probes"`
+
+	// PerEndpoint gives each endpoint of a host its own breaker, e.g. so that a failing log export doesn't stop
+	// prompt renders. By default, there is one breaker per host.
+	PerEndpoint bool `default:"false" envconfig:"PER_ENDPOINT" json:"per_endpoint"`
+}
+
+// Enabled reports whether requests go through circuit breakers.
+func (cfg *Breaker) Enabled() bool {
+	return cfg.FailureThreshold > 0
+}
+
+func (cfg *Breaker) Validate() error {
+	if cfg.FailureThreshold < 0 {
+		return ErrInvalidFailureThreshold
+	}
+
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.OpenDuration <= 0 {
+		return ErrInvalidOpenDuration
+	}
+
+	if cfg.HalfOpenProbes < 1 {
+		return ErrInvalidHalfOpenProbes
+	}
+
+	return nil
+}
@@ -1,23 +1,171 @@
 package config
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrServerAPIKeyRequired = errors.New(
+		"prompts and resources are loaded and refreshed with the server's portkey api key, outside of any client " +
+			"session, so it is required to enable them in client-required mode")
+	ErrDuplicateAPIKeyHeader = errors.New(
+		"portkey profiles that accept client keys must each use their own api key header")
+)
 
 type EnvVars struct {
-	LogLevel     LogLevel      `default:"info"            envconfig:"LOG_LEVEL"`
-	Portkey      Portkey       `envconfig:"PORTKEY"`
-	Tools        Tools         `envconfig:"TOOLS"`
-	Transport    TransportType `default:"stdio"           envconfig:"TRANSPORT"`
-	TransportSSE SSETransport  `envconfig:"TRANSPORT_SSE"`
+	Admin                   Admin                   `envconfig:"ADMIN"`
+	Auth                    Auth                    `envconfig:"AUTH"`
+	LogLevel                LogLevel                `default:"info"            envconfig:"LOG_LEVEL"`
+	Portkey                 Portkey                 `envconfig:"PORTKEY"`
+	PortkeyProfiles         Profiles                `envconfig:"PORTKEY"`
+	Prompts                 Prompts                 `envconfig:"PROMPTS"`
+	Resources               Resources               `envconfig:"RESOURCES"`
+	Results                 Results                 `envconfig:"RESULTS"`
+	Tools                   Tools                   `envconfig:"TOOLS"`
+	Tracing                 Tracing                 `envconfig:"TRACING"`
+	Transports              TransportTypes          `default:"stdio"           envconfig:"TRANSPORT"`
+	TransportSSE            SSETransport            `envconfig:"TRANSPORT_SSE"`
+	TransportStreamableHTTP StreamableHTTPTransport `envconfig:"TRANSPORT_STREAMABLE_HTTP"`
 }
 
 func (cfg *EnvVars) Validate() error {
+	if err := cfg.Admin.Validate(); err != nil {
+		return fmt.Errorf("error validating admin config: %w", err)
+	}
+
+	if err := cfg.Auth.Validate(); err != nil {
+		return fmt.Errorf("error validating auth config: %w", err)
+	}
+
 	if err := cfg.Portkey.Validate(); err != nil {
 		return fmt.Errorf("error validating portkey config: %w", err)
 	}
 
+	if err := cfg.PortkeyProfiles.Validate(); err != nil {
+		return fmt.Errorf("error validating portkey profiles: %w", err)
+	}
+
+	if err := cfg.validateAPIKeyHeaders(); err != nil {
+		return err
+	}
+
+	// Only client-required mode allows no server key. Prompts and resources can't use a client's key instead, since
+	// they are loaded at startup and refreshed in the background.
+	if cfg.Portkey.APIKeyMode == APIKeyModeClientRequired && cfg.Portkey.APIKey == "" &&
+		(cfg.Prompts.Enabled || cfg.Resources.Enabled) {
+		return ErrServerAPIKeyRequired
+	}
+
+	if err := cfg.Prompts.Validate(); err != nil {
+		return fmt.Errorf("error validating prompts config: %w", err)
+	}
+
+	if err := cfg.Resources.Validate(); err != nil {
+		return fmt.Errorf("error validating resources config: %w", err)
+	}
+
 	if err := cfg.Tools.Validate(); err != nil {
 		return fmt.Errorf("error validating tools config: %w", err)
 	}
 
+	if err := cfg.Tracing.Validate(); err != nil {
+		return fmt.Errorf("error validating tracing config: %w", err)
+	}
+
+	if cfg.Tracing.Exporter == TracingExporterStdout && cfg.Transports.Has(TransportStdio) {
+		return ErrStdoutTracingWithStdio
+	}
+
+	if err := cfg.Transports.Validate(); err != nil {
+		return fmt.Errorf("error validating transports: %w", err)
+	}
+
+	if cfg.Transports.Has(TransportSSE) {
+		if err := cfg.TransportSSE.Validate(); err != nil {
+			return fmt.Errorf("error validating sse transport config: %w", err)
+		}
+	}
+
+	if cfg.Transports.Has(TransportStreamableHTTP) {
+		if err := cfg.TransportStreamableHTTP.Validate(); err != nil {
+			return fmt.Errorf("error validating streamable http transport config: %w", err)
+		}
+	}
+
+	if cfg.Transports.Has(TransportSSE) && cfg.Transports.Has(TransportStreamableHTTP) &&
+		cfg.TransportSSE.Address == cfg.TransportStreamableHTTP.Address {
+		return fmt.Errorf("%w: sse and streamable-http both use %q", ErrConflictingTransports, cfg.TransportSSE.Address)
+	}
+
+	if err := cfg.validateAdminAddress(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateAPIKeyHeaders makes sure that a client's key is only ever taken for one profile, as it belongs to a single
+// org.
+func (cfg *EnvVars) validateAPIKeyHeaders() error {
+	profiles := make(map[string]string)
+
+	for _, name := range cfg.PortkeyProfiles.All() {
+		portkey, _ := cfg.PortkeyProfile(name)
+		if !portkey.AcceptsClientKeys() {
+			continue
+		}
+
+		header := http.CanonicalHeaderKey(portkey.APIKeyHeader)
+		if other, ok := profiles[header]; ok {
+			return fmt.Errorf("%w: %q and %q both use %q", ErrDuplicateAPIKeyHeader, other, name, header)
+		}
+
+		profiles[header] = name
+	}
+
+	return nil
+}
+
+// ClientKeyHeaders returns the API key header of each profile that accepts client keys, by profile name.
+func (cfg *EnvVars) ClientKeyHeaders() map[string]string {
+	headers := make(map[string]string)
+
+	for _, name := range cfg.PortkeyProfiles.All() {
+		if portkey, _ := cfg.PortkeyProfile(name); portkey.AcceptsClientKeys() {
+			headers[name] = portkey.APIKeyHeader
+		}
+	}
+
+	return headers
+}
+
+// validateAdminAddress makes sure that the admin endpoints don't try to listen on the address of an HTTP transport,
+// which serves them already.
+func (cfg *EnvVars) validateAdminAddress() error {
+	if cfg.Admin.Address == "" {
+		return nil
+	}
+
+	if cfg.Transports.Has(TransportSSE) && cfg.Admin.Address == cfg.TransportSSE.Address {
+		return fmt.Errorf("%w: admin and sse both use %q", ErrConflictingTransports, cfg.Admin.Address)
+	}
+
+	if cfg.Transports.Has(TransportStreamableHTTP) && cfg.Admin.Address == cfg.TransportStreamableHTTP.Address {
+		return fmt.Errorf("%w: admin and streamable-http both use %q", ErrConflictingTransports, cfg.Admin.Address)
+	}
+
 	return nil
 }
+
+// PortkeyProfile returns the Portkey config of the named profile, where DefaultProfile is the PORTKEY_* config itself.
+func (cfg *EnvVars) PortkeyProfile(name string) (Portkey, bool) {
+	if name == DefaultProfile {
+		return cfg.Portkey, true
+	}
+
+	portkey, ok := cfg.PortkeyProfiles.Portkey[name]
+
+	return portkey, ok
+}
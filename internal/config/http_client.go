@@ -22,6 +22,8 @@ type HTTPClient struct {
 	CustomCACertPath   string        `envconfig:"CUSTOM_CA_CERT_PATH" json:"custom_ca_cert_path"`
 	InsecureSkipVerify bool          `default:"false"                 envconfig:"INSECURE_SKIP_VERIFY" json:"insecure_skip_verify"` //nolint:lll
 	Timeout            time.Duration `default:"30s"                   envconfig:"TIMEOUT"              json:"timeout"`
+	Retry              Retry         `envconfig:"RETRY"               json:"retry"`
+	Breaker            Breaker       `envconfig:"BREAKER"             json:"breaker"`
 }
 
 func (cfg *HTTPClient) FromConfig() (*http.Client, error) {
@@ -67,5 +69,13 @@ func (cfg *HTTPClient) Validate() error {
 		return ErrInvalidTimeout
 	}
 
+	if err := cfg.Retry.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := cfg.Breaker.Validate(); err != nil {
+		return fmt.Errorf("invalid circuit breaker: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidClockSkew     = errors.New("clock skew must not be negative")
+	ErrInvalidJWKSCacheTTL  = errors.New("jwks cache ttl must be positive")
+	ErrInvalidOAuthResource = errors.New("oauth resource must be an absolute url")
+	ErrInvalidOAuthIssuer   = errors.New("oauth issuer must be an absolute url")
+	ErrInvalidToolScope     = errors.New("tool scope must be \"tool:scope\"")
+	ErrJWKSFileNotExist     = errors.New("jwks file does not exist")
+	ErrJWKSSource           = errors.New("exactly one of jwks url and jwks file must be set")
+)
+
+// AnyTool is the ToolScopes key that sets the scope needed for tools that aren't listed by name.
+const AnyTool = "*"
+
+// OAuth configures the server as an OAuth 2.1 protected resource, per the MCP authorization spec. Access tokens are
+// JWTs issued by Issuer, and are verified against its JWKS.
+type OAuth struct {
+	// Issuer is the authorization server that issues access tokens. OAuth is enabled when it is set.
+	Issuer string `envconfig:"ISSUER"`
+
+	// Resource is the canonical URL of this server's MCP endpoint, e.g. https://mcp.example.com/mcp. It is advertised
+	// in the protected resource metadata, and is the audience that tokens must be issued for unless Audiences is set.
+	Resource  string   `envconfig:"RESOURCE"`
+	Audiences []string `envconfig:"AUDIENCES"`
+
+	JWKSURL      string        `envconfig:"JWKS_URL"`
+	JWKSFile     string        `envconfig:"JWKS_FILE"`
+	JWKSCacheTTL time.Duration `default:"1h" envconfig:"JWKS_CACHE_TTL"`
+
+	// RequiredScopes must all be granted to a token for it to be accepted at all.
+	RequiredScopes []string `envconfig:"REQUIRED_SCOPES"`
+
+	// ToolScopes maps tool names to the scope that a token needs to list and call them, e.g.
+	// "prompt_create:portkey.write,*:portkey.read". The "*" entry applies to tools that aren't listed by name; without
+	// it, those tools need no scope beyond RequiredScopes.
+	ToolScopes ToolScopes `envconfig:"TOOL_SCOPES"`
+
+	// ClockSkew is how far token expiry and not-before times may be off from the server's clock.
+	ClockSkew time.Duration `default:"1m" envconfig:"CLOCK_SKEW"`
+}
+
+// ToolScopes maps tool names to the scope needed to list and call them.
+type ToolScopes map[string]string
+
+// Decode implements the envconfig.Decoder interface. Only the first ':' of each pair separates the tool name from the
+// scope, since scopes often contain colons themselves, e.g. "prompt_create:portkey:write".
+func (ts *ToolScopes) Decode(value string) error {
+	scopes := make(ToolScopes)
+
+	for pair := range strings.SplitSeq(value, ",") {
+		tool, scope, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || tool == "" || scope == "" {
+			return fmt.Errorf("%w: %q", ErrInvalidToolScope, pair)
+		}
+
+		scopes[tool] = scope
+	}
+
+	*ts = scopes
+
+	return nil
+}
+
+// Enabled reports whether OAuth access tokens are accepted.
+func (cfg *OAuth) Enabled() bool {
+	return cfg.Issuer != ""
+}
+
+// AcceptedAudiences returns the audiences that a token may be issued for.
+func (cfg *OAuth) AcceptedAudiences() []string {
+	if len(cfg.Audiences) > 0 {
+		return cfg.Audiences
+	}
+
+	return []string{cfg.Resource}
+}
+
+// ScopesSupported returns every scope that the server checks for, sorted.
+func (cfg *OAuth) ScopesSupported() []string {
+	scopes := slices.Clone(cfg.RequiredScopes)
+
+	for _, scope := range cfg.ToolScopes {
+		scopes = append(scopes, scope)
+	}
+
+	slices.Sort(scopes)
+
+	return slices.Compact(scopes)
+}
+
+func (cfg *OAuth) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if u, err := url.Parse(cfg.Issuer); err != nil || !u.IsAbs() {
+		return fmt.Errorf("%w: %q", ErrInvalidOAuthIssuer, cfg.Issuer)
+	}
+
+	if u, err := url.Parse(cfg.Resource); err != nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidOAuthResource, cfg.Resource)
+	}
+
+	if (cfg.JWKSURL == "") == (cfg.JWKSFile == "") {
+		return ErrJWKSSource
+	}
+
+	if cfg.JWKSFile != "" {
+		if _, err := os.Stat(cfg.JWKSFile); os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrJWKSFileNotExist, cfg.JWKSFile)
+		}
+	}
+
+	if cfg.JWKSCacheTTL <= 0 {
+		return ErrInvalidJWKSCacheTTL
+	}
+
+	if cfg.ClockSkew < 0 {
+		return ErrInvalidClockSkew
+	}
+
+	return nil
+}
@@ -8,14 +8,61 @@ import (
 )
 
 var (
-	ErrBaseURLRequired = errors.New("base url is required")
-	ErrAPIKeyRequired  = errors.New("api key is required")
+	ErrBaseURLRequired     = errors.New("base url is required")
+	ErrAPIKeyRequired      = errors.New("api key is required")
+	ErrAPIKeyHeaderMissing = errors.New("api key header is required")
+	ErrInvalidAPIKeyMode   = errors.New("invalid api key mode")
 )
 
+// APIKeyMode decides whose Portkey API key is used for requests made on behalf of a client.
+type APIKeyMode string
+
+const (
+	// APIKeyModeServer always uses the server's own API key, ignoring any key provided by the client.
+	APIKeyModeServer APIKeyMode = "server"
+
+	// APIKeyModeClientRequired only uses the client's key, and fails requests from clients that didn't provide one.
+	APIKeyModeClientRequired APIKeyMode = "client-required"
+
+	// APIKeyModeClientPreferred uses the client's key when it provided one, and the server's key otherwise.
+	APIKeyModeClientPreferred APIKeyMode = "client-preferred"
+)
+
+// Decode implements the envconfig.Decoder interface.
+func (m *APIKeyMode) Decode(value string) error {
+	val := APIKeyMode(value)
+	switch val {
+	case APIKeyModeServer, APIKeyModeClientRequired, APIKeyModeClientPreferred:
+		*m = val
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be one of: %s, %s, %s",
+			ErrInvalidAPIKeyMode, value, APIKeyModeServer, APIKeyModeClientRequired, APIKeyModeClientPreferred)
+	}
+}
+
 type Portkey struct {
-	APIKey  types.MaskedString `envconfig:"API_KEY"                 json:"api_key"       required:"true"`
-	BaseURL string             `default:"https://api.portkey.ai/v1" envconfig:"BASE_URL" json:"base_url" required:"true"` //nolint:lll
-	Client  HTTPClient         `envconfig:"CLIENT"                  json:"client"`
+	// APIKey is the server's own key. It is only optional in client-required mode, where it is still used for the
+	// server's background work, like listing prompts for MCP prompts and resources.
+	APIKey types.MaskedString `envconfig:"API_KEY" json:"api_key"`
+
+	APIKeyMode APIKeyMode `default:"server" envconfig:"API_KEY_MODE" json:"api_key_mode"`
+
+	// APIKeyHeader is the HTTP header that clients of the HTTP transports send their own key in.
+	APIKeyHeader string `default:"X-Portkey-Api-Key" envconfig:"API_KEY_HEADER" json:"api_key_header"`
+
+	BaseURL string     `default:"https://api.portkey.ai/v1" envconfig:"BASE_URL" json:"base_url" required:"true"` //nolint:lll
+	Client  HTTPClient `envconfig:"CLIENT"                  json:"client"`
+
+	// Profile is the name of the profile that this is the config of. Clients' keys are only used for the profile that
+	// they were provided for.
+	Profile string `ignored:"true" json:"profile"`
+}
+
+// AcceptsClientKeys reports whether clients may call this profile with their own key.
+func (cfg *Portkey) AcceptsClientKeys() bool {
+	return cfg.APIKeyMode != APIKeyModeServer
 }
 
 func (cfg *Portkey) Validate() error {
@@ -23,10 +70,14 @@ func (cfg *Portkey) Validate() error {
 		return ErrBaseURLRequired
 	}
 
-	if cfg.APIKey == "" {
+	if cfg.APIKey == "" && cfg.APIKeyMode != APIKeyModeClientRequired {
 		return ErrAPIKeyRequired
 	}
 
+	if cfg.APIKeyHeader == "" {
+		return ErrAPIKeyHeaderMissing
+	}
+
 	if err := cfg.Client.Validate(); err != nil {
 		return fmt.Errorf("invalid http client: %w", err)
 	}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrDuplicateProfile      = errors.New("duplicate portkey profile")
+	ErrInvalidProfileName    = errors.New("portkey profile names must be lowercase letters, digits and underscores")
+	ErrProfileNotConfigured  = errors.New("portkey profile is not configured")
+	ErrUnknownDefaultProfile = errors.New("default portkey profile is not configured")
+)
+
+// DefaultProfile is the name of the profile configured by the PORTKEY_* variables themselves.
+const DefaultProfile = "default"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`) //nolint:gochecknoglobals
+
+// Profiles are named Portkey configurations that tools can be called with, besides the default one, e.g. for separate
+// staging and production orgs, or a self-hosted gateway.
+type Profiles struct {
+	// Names lists the named profiles. Each one is configured by PORTKEY_PROFILE_<NAME>_* variables, which are the same
+	// as the PORTKEY_* ones, except that a profile's API key mode is PORTKEY_API_KEY_MODE unless it sets its own.
+	Names []string `envconfig:"PROFILES" json:"names"`
+
+	// Default is the profile used by tool calls that don't name one.
+	Default string `default:"default" envconfig:"DEFAULT_PROFILE" json:"default"`
+
+	// Portkey holds the config of each named profile, by name. It is loaded separately, since envconfig can't load
+	// variables whose names depend on other variables.
+	Portkey map[string]Portkey `ignored:"true" json:"portkey"`
+}
+
+// EnvPrefix returns the prefix of the environment variables that configure the named profile.
+func (cfg *Profiles) EnvPrefix(name string) string {
+	return "PORTKEY_PROFILE_" + strings.ToUpper(name)
+}
+
+// All returns the names of every profile, starting with the default one.
+func (cfg *Profiles) All() []string {
+	return append([]string{DefaultProfile}, cfg.Names...)
+}
+
+// Enabled reports whether any named profiles are configured.
+func (cfg *Profiles) Enabled() bool {
+	return len(cfg.Names) > 0
+}
+
+func (cfg *Profiles) Validate() error {
+	seen := make(map[string]bool, len(cfg.Names))
+
+	for _, name := range cfg.Names {
+		if !profileNamePattern.MatchString(name) || name == DefaultProfile {
+			return fmt.Errorf("%w, and not %q: %q", ErrInvalidProfileName, DefaultProfile, name)
+		}
+
+		if seen[name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateProfile, name)
+		}
+
+		seen[name] = true
+
+		portkey, ok := cfg.Portkey[name]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrProfileNotConfigured, name)
+		}
+
+		if err := portkey.Validate(); err != nil {
+			return fmt.Errorf("error validating portkey profile %q: %w", name, err)
+		}
+	}
+
+	if !slices.Contains(cfg.All(), cfg.Default) {
+		return fmt.Errorf("%w: %q", ErrUnknownDefaultProfile, cfg.Default)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidRefreshInterval = errors.New("refresh interval must not be negative")
+
+// Prompts configures which Portkey prompts are exposed as native MCP prompts.
+type Prompts struct {
+	// Enabled exposes Portkey prompts through prompts/list and prompts/get. It is off by default, since every prompt
+	// is listed and fetched at startup and on every refresh, unless limited to a curated subset.
+	Enabled bool `default:"false" envconfig:"ENABLED" json:"enabled"`
+
+	// CollectionIDs limits the exposed prompts to these collections. All collections are exposed when empty.
+	CollectionIDs []string `envconfig:"COLLECTION_IDS" json:"collection_ids"`
+
+	// WorkspaceID limits the exposed prompts to this workspace.
+	WorkspaceID string `envconfig:"WORKSPACE_ID" json:"workspace_id"`
+
+	// RefreshInterval is how often the prompt list is refreshed from Portkey. Zero only loads prompts at startup.
+	RefreshInterval time.Duration `default:"5m" envconfig:"REFRESH_INTERVAL" json:"refresh_interval"`
+}
+
+func (cfg *Prompts) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return ErrInvalidRefreshInterval
+	}
+
+	return nil
+}
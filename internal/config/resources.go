@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPollInterval = errors.New("poll interval must be positive")
+	ErrInvalidPollJitter   = errors.New("poll jitter must not be negative")
+)
+
+// Resources configures the MCP resources for Portkey prompts, collections and configs.
+type Resources struct {
+	// Enabled exposes Portkey prompts, collections and configs through resources/list and resources/read. It is off
+	// by default, since every prompt is listed at startup and on every refresh.
+	Enabled bool `default:"false" envconfig:"ENABLED" json:"enabled"`
+
+	// RefreshInterval is how often the listed prompt resources are refreshed from Portkey. Zero only lists prompts at
+	// startup.
+	RefreshInterval time.Duration `default:"5m" envconfig:"REFRESH_INTERVAL" json:"refresh_interval"`
+
+	Subscriptions ResourceSubscriptions `envconfig:"SUBSCRIPTIONS" json:"subscriptions"`
+}
+
+func (cfg *Resources) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return ErrInvalidRefreshInterval
+	}
+
+	if cfg.Enabled {
+		if err := cfg.Subscriptions.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResourceSubscriptions configures how prompt changes are detected and sent to clients subscribed to resources.
+type ResourceSubscriptions struct {
+	// Enabled lets clients subscribe to resources, and polls Portkey for prompt changes. It is off by default, like
+	// the resources themselves.
+	Enabled bool `default:"false" envconfig:"ENABLED" json:"enabled"`
+
+	// CollectionIDs limits change detection to these collections. All collections are watched when empty.
+	CollectionIDs []string `envconfig:"COLLECTION_IDS" json:"collection_ids"`
+
+	// WorkspaceID limits change detection to this workspace.
+	WorkspaceID string `envconfig:"WORKSPACE_ID" json:"workspace_id"`
+
+	// PollInterval is how often the prompt list is polled for changes.
+	PollInterval time.Duration `default:"1m" envconfig:"POLL_INTERVAL" json:"poll_interval"`
+
+	// PollJitter is the maximum random delay added to each poll interval, so that replicas don't poll in lockstep.
+	PollJitter time.Duration `default:"10s" envconfig:"POLL_JITTER" json:"poll_jitter"`
+}
+
+func (cfg *ResourceSubscriptions) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.PollInterval <= 0 {
+		return ErrInvalidPollInterval
+	}
+
+	if cfg.PollJitter < 0 {
+		return ErrInvalidPollJitter
+	}
+
+	return nil
+}
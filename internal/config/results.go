@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidResultFormat = errors.New("invalid result format")
+
+// ResultFormat is how a tool result is presented to the agent.
+type ResultFormat string
+
+const (
+	ResultFormatJSON     ResultFormat = "json"
+	ResultFormatMarkdown ResultFormat = "markdown"
+	ResultFormatBoth     ResultFormat = "both"
+)
+
+// ResultFormats returns every result format.
+func ResultFormats() []string {
+	return []string{string(ResultFormatJSON), string(ResultFormatMarkdown), string(ResultFormatBoth)}
+}
+
+// Decode implements the envconfig.Decoder interface.
+func (f *ResultFormat) Decode(value string) error {
+	val := ResultFormat(value)
+	switch val {
+	case ResultFormatJSON, ResultFormatMarkdown, ResultFormatBoth:
+		*f = val
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be one of: %s, %s, %s",
+			ErrInvalidResultFormat, value, ResultFormatJSON, ResultFormatMarkdown, ResultFormatBoth)
+	}
+}
+
+// Results configures how tool results are formatted.
+type Results struct {
+	// DefaultFormat is used when a tool call doesn't ask for a format.
+	DefaultFormat ResultFormat `default:"json" envconfig:"DEFAULT_FORMAT" json:"default_format"`
+
+	// PreserveUnknownFields keeps the fields of Portkey responses that this server doesn't know about in JSON results.
+	// They are dropped by default. Structured content only ever holds known fields.
+	PreserveUnknownFields bool `default:"false" envconfig:"PRESERVE_UNKNOWN_FIELDS" json:"preserve_unknown_fields"`
+}
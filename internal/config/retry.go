@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrInvalidMaxAttempts      = errors.New("max attempts must be at least 1")
+	ErrInvalidBackoff          = errors.New("base backoff must be positive, and no greater than max backoff")
+	ErrInvalidJitter           = errors.New("jitter must be between 0 and 1")
+	ErrInvalidRetryStatusCodes = errors.New("retryable status codes must be 4xx or 5xx")
+)
+
+// Retry configures how requests to Portkey are retried after transient failures: connection resets, and responses
+// with one of StatusCodes. Only idempotent requests are retried, unless their context allows otherwise.
+type Retry struct {
+	// MaxAttempts counts the first attempt too, so 1 disables retries.
+	MaxAttempts int `default:"3" envconfig:"MAX_ATTEMPTS" json:"max_attempts"`
+
+	// BaseBackoff is the wait before the first retry, which doubles for each further retry up to MaxBackoff. A longer
+	// Retry-After from Portkey takes precedence.
+	BaseBackoff time.Duration `default:"250ms" envconfig:"BASE_BACKOFF" json:"base_backoff"`
+	MaxBackoff  time.Duration `default:"5s"    envconfig:"MAX_BACKOFF"  json:"max_backoff"`
+
+	// Jitter is the fraction of each backoff that is randomized away, so that clients don't retry in lockstep.
+	Jitter float64 `default:"0.2" envconfig:"JITTER" json:"jitter"`
+
+	StatusCodes []int `default:"429,502,503,504" envconfig:"STATUS_CODES" json:"status_codes"`
+
+	// AllowRender retries prompt renders too, which are POSTs, but change nothing.
+	AllowRender bool `default:"true" envconfig:"ALLOW_RENDER" json:"allow_render"`
+}
+
+func (cfg *Retry) Validate() error {
+	if cfg.MaxAttempts < 1 {
+		return ErrInvalidMaxAttempts
+	}
+
+	if cfg.BaseBackoff <= 0 || cfg.MaxBackoff < cfg.BaseBackoff {
+		return ErrInvalidBackoff
+	}
+
+	if cfg.Jitter < 0 || cfg.Jitter > 1 {
+		return ErrInvalidJitter
+	}
+
+	for _, code := range cfg.StatusCodes {
+		if code < http.StatusBadRequest || code > 599 { //nolint:mnd
+			return fmt.Errorf("%w: %d", ErrInvalidRetryStatusCodes, code)
+		}
+	}
+
+	return nil
+}
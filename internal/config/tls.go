@@ -0,0 +1,130 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var (
+	ErrAppendClientCACert     = errors.New("failed to append client CA cert")
+	ErrInvalidTLSVersion      = errors.New("invalid tls version")
+	ErrInvalidReloadInterval  = errors.New("reload interval must not be negative")
+	ErrLoadTLSCertificate     = errors.New("failed to load tls certificate")
+	ErrReadClientCACert       = errors.New("failed to read client CA cert")
+	ErrTLSCertKeyPair         = errors.New("tls cert path and key path must be set together")
+	ErrTLSClientCAWithoutCert = errors.New("client CA cert path requires a tls cert and key")
+)
+
+// TLSVersion is a minimum TLS version, written as "1.2" or "1.3".
+type TLSVersion uint16
+
+// Decode implements the envconfig.Decoder interface.
+func (v *TLSVersion) Decode(value string) error {
+	switch value {
+	case "1.2":
+		*v = tls.VersionTLS12
+	case "1.3":
+		*v = tls.VersionTLS13
+	default:
+		return fmt.Errorf("%w: %q, must be one of: 1.2, 1.3", ErrInvalidTLSVersion, value)
+	}
+
+	return nil
+}
+
+func (v TLSVersion) String() string {
+	return tls.VersionName(uint16(v))
+}
+
+// TLS configures TLS for an HTTP transport. TLS is enabled when a certificate is configured, and mutual TLS when a
+// client CA is configured as well.
+type TLS struct {
+	CertPath string `envconfig:"CERT_PATH"`
+	KeyPath  string `envconfig:"KEY_PATH"`
+
+	// ClientCACertPath is a PEM bundle of the CAs that client certificates must be signed by. Clients without a valid
+	// certificate are rejected during the handshake.
+	ClientCACertPath string `envconfig:"CLIENT_CA_CERT_PATH"`
+
+	MinVersion TLSVersion `default:"1.2" envconfig:"MIN_VERSION"`
+
+	// ReloadInterval is how often the certificate, key and client CA files are checked for changes, so that renewed
+	// certificates are picked up without a restart. Zero disables reloading.
+	ReloadInterval time.Duration `default:"1m" envconfig:"RELOAD_INTERVAL"`
+}
+
+// Enabled reports whether the transport should serve TLS.
+func (cfg *TLS) Enabled() bool {
+	return cfg.CertPath != ""
+}
+
+// Paths returns the files that the TLS config is loaded from.
+func (cfg *TLS) Paths() []string {
+	paths := []string{cfg.CertPath, cfg.KeyPath}
+
+	if cfg.ClientCACertPath != "" {
+		paths = append(paths, cfg.ClientCACertPath)
+	}
+
+	return paths
+}
+
+// FromConfig reads the certificate, key and client CA files into a server TLS config.
+func (cfg *TLS) FromConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertPath, cfg.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadTLSCertificate, err)
+	}
+
+	tlsConfig := &tls.Config{ //nolint:exhaustruct
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   uint16(cfg.MinVersion),
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if cfg.ClientCACertPath != "" {
+		caCert, err := os.ReadFile(cfg.ClientCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReadClientCACert, err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return nil, ErrAppendClientCACert
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func (cfg *TLS) Validate() error {
+	if (cfg.CertPath == "") != (cfg.KeyPath == "") {
+		return ErrTLSCertKeyPair
+	}
+
+	if cfg.ClientCACertPath != "" && !cfg.Enabled() {
+		return ErrTLSClientCAWithoutCert
+	}
+
+	if cfg.ReloadInterval < 0 {
+		return ErrInvalidReloadInterval
+	}
+
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	// Fail at startup, rather than on the first handshake, if the files are missing or invalid.
+	if _, err := cfg.FromConfig(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LogExportStartTool holds configuration for the log_export_start tool, which can poll an export until it finishes.
+type LogExportStartTool struct {
+	// Description will override the default description of the tool.
+	Description string `envconfig:"DESCRIPTION" required:"false"`
+
+	// Enabled will disable the tool if set to false.
+	Enabled bool `default:"true" envconfig:"ENABLED" required:"false"`
+
+	// PollInterval is how often the export's status is checked while waiting for it to finish.
+	PollInterval time.Duration `default:"5s" envconfig:"POLL_INTERVAL" required:"false"`
+}
+
+// Validate validates the LogExportStartTool configuration.
+func (t *LogExportStartTool) Validate(envPrefix string) error {
+	if t.PollInterval <= 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPollInterval, envPrefix+"_POLL_INTERVAL")
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package config
+
+// WriteTool holds configuration for tools that create, change or delete Portkey resources. Unlike other tools, they
+// are disabled unless enabled, so that a connected model can't change an account without the operator choosing that.
+type WriteTool struct {
+	// Description will override the default description of the tool.
+	Description string `envconfig:"DESCRIPTION" required:"false"`
+
+	// Enabled will enable the tool if set to true.
+	Enabled bool `default:"false" envconfig:"ENABLED" required:"false"`
+}
+
+// Validate validates the WriteTool configuration.
+func (t *WriteTool) Validate(envPrefix string) error {
+	_ = envPrefix + "_DESCRIPTION"
+
+	return nil
+}
@@ -3,39 +3,155 @@ package config
 import "fmt"
 
 const (
-	envPrefixTools        = "TOOLS"
-	envPrefixPromptCreate = "PROMPT_CREATE"
-	envPrefixPromptRender = "PROMPT_RENDER"
-	envPrefixPromptsList  = "PROMPTS_LIST"
+	envPrefixTools                       = "TOOLS"
+	envPrefixAnalyticsGraph              = "ANALYTICS_GRAPH"
+	envPrefixAnalyticsGroup              = "ANALYTICS_GROUP"
+	envPrefixFeedbackCreate              = "FEEDBACK_CREATE"
+	envPrefixFeedbackUpdate              = "FEEDBACK_UPDATE"
+	envPrefixGuardrailCreate             = "GUARDRAIL_CREATE"
+	envPrefixGuardrailDelete             = "GUARDRAIL_DELETE"
+	envPrefixGuardrailGet                = "GUARDRAIL_GET"
+	envPrefixGuardrailUpdate             = "GUARDRAIL_UPDATE"
+	envPrefixGuardrailsList              = "GUARDRAILS_LIST"
+	envPrefixIntegrationCreate           = "INTEGRATION_CREATE"
+	envPrefixIntegrationGet              = "INTEGRATION_GET"
+	envPrefixIntegrationModelsList       = "INTEGRATION_MODELS_LIST"
+	envPrefixIntegrationModelsUpdate     = "INTEGRATION_MODELS_UPDATE"
+	envPrefixIntegrationUpdate           = "INTEGRATION_UPDATE"
+	envPrefixIntegrationWorkspacesList   = "INTEGRATION_WORKSPACES_LIST"
+	envPrefixIntegrationWorkspacesUpdate = "INTEGRATION_WORKSPACES_UPDATE"
+	envPrefixIntegrationsList            = "INTEGRATIONS_LIST"
+	envPrefixLogExportCreate             = "LOG_EXPORT_CREATE"
+	envPrefixLogExportDownload           = "LOG_EXPORT_DOWNLOAD"
+	envPrefixLogExportGet                = "LOG_EXPORT_GET"
+	envPrefixLogExportStart              = "LOG_EXPORT_START"
+	envPrefixLogsSearch                  = "LOGS_SEARCH"
+	envPrefixPromptCreate                = "PROMPT_CREATE"
+	envPrefixPromptRender                = "PROMPT_RENDER"
+	envPrefixPromptsList                 = "PROMPTS_LIST"
+	envPrefixProvidersList               = "PROVIDERS_LIST"
+	envPrefixRateLimitCreate             = "RATE_LIMIT_CREATE"
+	envPrefixRateLimitDelete             = "RATE_LIMIT_DELETE"
+	envPrefixRateLimitUpdate             = "RATE_LIMIT_UPDATE"
+	envPrefixRateLimitsList              = "RATE_LIMITS_LIST"
+	envPrefixTraceGet                    = "TRACE_GET"
+	envPrefixUsageLimitCreate            = "USAGE_LIMIT_CREATE"
+	envPrefixUsageLimitDelete            = "USAGE_LIMIT_DELETE"
+	envPrefixUsageLimitUpdate            = "USAGE_LIMIT_UPDATE"
+	envPrefixUsageLimitsConsumption      = "USAGE_LIMITS_CONSUMPTION"
+	envPrefixUsageLimitsList             = "USAGE_LIMITS_LIST"
 )
 
 type Tools struct {
-	PromptCreate BaseTool `envconfig:"PROMPT_CREATE"`
-	PromptRender BaseTool `envconfig:"PROMPT_RENDER"`
-	PromptsList  BaseTool `envconfig:"PROMPTS_LIST"`
+	AnalyticsGraph              BaseTool           `envconfig:"ANALYTICS_GRAPH"`
+	AnalyticsGroup              BaseTool           `envconfig:"ANALYTICS_GROUP"`
+	FeedbackCreate              BaseTool           `envconfig:"FEEDBACK_CREATE"`
+	FeedbackUpdate              BaseTool           `envconfig:"FEEDBACK_UPDATE"`
+	GuardrailCreate             WriteTool          `envconfig:"GUARDRAIL_CREATE"`
+	GuardrailDelete             WriteTool          `envconfig:"GUARDRAIL_DELETE"`
+	GuardrailGet                BaseTool           `envconfig:"GUARDRAIL_GET"`
+	GuardrailUpdate             WriteTool          `envconfig:"GUARDRAIL_UPDATE"`
+	GuardrailsList              BaseTool           `envconfig:"GUARDRAILS_LIST"`
+	IntegrationCreate           WriteTool          `envconfig:"INTEGRATION_CREATE"`
+	IntegrationGet              BaseTool           `envconfig:"INTEGRATION_GET"`
+	IntegrationModelsList       BaseTool           `envconfig:"INTEGRATION_MODELS_LIST"`
+	IntegrationModelsUpdate     WriteTool          `envconfig:"INTEGRATION_MODELS_UPDATE"`
+	IntegrationUpdate           WriteTool          `envconfig:"INTEGRATION_UPDATE"`
+	IntegrationWorkspacesList   BaseTool           `envconfig:"INTEGRATION_WORKSPACES_LIST"`
+	IntegrationWorkspacesUpdate WriteTool          `envconfig:"INTEGRATION_WORKSPACES_UPDATE"`
+	IntegrationsList            BaseTool           `envconfig:"INTEGRATIONS_LIST"`
+	LogExportCreate             BaseTool           `envconfig:"LOG_EXPORT_CREATE"`
+	LogExportDownload           BaseTool           `envconfig:"LOG_EXPORT_DOWNLOAD"`
+	LogExportGet                BaseTool           `envconfig:"LOG_EXPORT_GET"`
+	LogExportStart              LogExportStartTool `envconfig:"LOG_EXPORT_START"`
+	LogsSearch                  BaseTool           `envconfig:"LOGS_SEARCH"`
+	PromptCreate                BaseTool           `envconfig:"PROMPT_CREATE"`
+	PromptRender                BaseTool           `envconfig:"PROMPT_RENDER"`
+	PromptsList                 BaseTool           `envconfig:"PROMPTS_LIST"`
+	ProvidersList               BaseTool           `envconfig:"PROVIDERS_LIST"`
+	RateLimitCreate             WriteTool          `envconfig:"RATE_LIMIT_CREATE"`
+	RateLimitDelete             WriteTool          `envconfig:"RATE_LIMIT_DELETE"`
+	RateLimitUpdate             WriteTool          `envconfig:"RATE_LIMIT_UPDATE"`
+	RateLimitsList              BaseTool           `envconfig:"RATE_LIMITS_LIST"`
+	TraceGet                    BaseTool           `envconfig:"TRACE_GET"`
+	UsageLimitCreate            WriteTool          `envconfig:"USAGE_LIMIT_CREATE"`
+	UsageLimitDelete            WriteTool          `envconfig:"USAGE_LIMIT_DELETE"`
+	UsageLimitUpdate            WriteTool          `envconfig:"USAGE_LIMIT_UPDATE"`
+	UsageLimitsConsumption      BaseTool           `envconfig:"USAGE_LIMITS_CONSUMPTION"`
+	UsageLimitsList             BaseTool           `envconfig:"USAGE_LIMITS_LIST"`
 }
 
-func (t *Tools) Validate() error {
-	envPrefixPromptCreateTool := fmt.Sprintf("%s_%s", envPrefixTools, envPrefixPromptCreate)
+// toolConfig is the configuration of a tool, e.g. a BaseTool or a WriteTool.
+type toolConfig interface {
+	Validate(envPrefix string) error
+}
 
-	err := t.PromptCreate.Validate(envPrefixPromptCreateTool)
-	if err != nil {
-		return fmt.Errorf("error validating prompt create tool: %w", err)
-	}
+// namedTool pairs a tool's configuration with the names used to refer to it in env vars and error messages.
+type namedTool struct {
+	name      string
+	envPrefix string
+	cfg       toolConfig
+}
 
-	envPrefixPromptRenderTool := fmt.Sprintf("%s_%s", envPrefixTools, envPrefixPromptRender)
+func (t *Tools) Validate() error {
+	for _, tool := range t.all() {
+		envPrefixTool := fmt.Sprintf("%s_%s", envPrefixTools, tool.envPrefix)
 
-	err = t.PromptRender.Validate(envPrefixPromptRenderTool)
-	if err != nil {
-		return fmt.Errorf("error validating prompt render tool: %w", err)
+		err := tool.cfg.Validate(envPrefixTool)
+		if err != nil {
+			return fmt.Errorf("error validating %s tool: %w", tool.name, err)
+		}
 	}
 
-	envPrefixPromptsListTool := fmt.Sprintf("%s_%s", envPrefixTools, envPrefixPromptsList)
+	return nil
+}
 
-	err = t.PromptsList.Validate(envPrefixPromptsListTool)
-	if err != nil {
-		return fmt.Errorf("error validating prompts list tool: %w", err)
+func (t *Tools) all() []namedTool {
+	return []namedTool{
+		{name: "analytics graph", envPrefix: envPrefixAnalyticsGraph, cfg: &t.AnalyticsGraph},
+		{name: "analytics group", envPrefix: envPrefixAnalyticsGroup, cfg: &t.AnalyticsGroup},
+		{name: "feedback create", envPrefix: envPrefixFeedbackCreate, cfg: &t.FeedbackCreate},
+		{name: "feedback update", envPrefix: envPrefixFeedbackUpdate, cfg: &t.FeedbackUpdate},
+		{name: "guardrail create", envPrefix: envPrefixGuardrailCreate, cfg: &t.GuardrailCreate},
+		{name: "guardrail delete", envPrefix: envPrefixGuardrailDelete, cfg: &t.GuardrailDelete},
+		{name: "guardrail get", envPrefix: envPrefixGuardrailGet, cfg: &t.GuardrailGet},
+		{name: "guardrail update", envPrefix: envPrefixGuardrailUpdate, cfg: &t.GuardrailUpdate},
+		{name: "guardrails list", envPrefix: envPrefixGuardrailsList, cfg: &t.GuardrailsList},
+		{name: "integration create", envPrefix: envPrefixIntegrationCreate, cfg: &t.IntegrationCreate},
+		{name: "integration get", envPrefix: envPrefixIntegrationGet, cfg: &t.IntegrationGet},
+		{name: "integration models list", envPrefix: envPrefixIntegrationModelsList, cfg: &t.IntegrationModelsList},
+		{
+			name: "integration models update", envPrefix: envPrefixIntegrationModelsUpdate,
+			cfg: &t.IntegrationModelsUpdate,
+		},
+		{name: "integration update", envPrefix: envPrefixIntegrationUpdate, cfg: &t.IntegrationUpdate},
+		{
+			name: "integration workspaces list", envPrefix: envPrefixIntegrationWorkspacesList,
+			cfg: &t.IntegrationWorkspacesList,
+		},
+		{
+			name: "integration workspaces update", envPrefix: envPrefixIntegrationWorkspacesUpdate,
+			cfg: &t.IntegrationWorkspacesUpdate,
+		},
+		{name: "integrations list", envPrefix: envPrefixIntegrationsList, cfg: &t.IntegrationsList},
+		{name: "log export create", envPrefix: envPrefixLogExportCreate, cfg: &t.LogExportCreate},
+		{name: "log export download", envPrefix: envPrefixLogExportDownload, cfg: &t.LogExportDownload},
+		{name: "log export get", envPrefix: envPrefixLogExportGet, cfg: &t.LogExportGet},
+		{name: "log export start", envPrefix: envPrefixLogExportStart, cfg: &t.LogExportStart},
+		{name: "logs search", envPrefix: envPrefixLogsSearch, cfg: &t.LogsSearch},
+		{name: "prompt create", envPrefix: envPrefixPromptCreate, cfg: &t.PromptCreate},
+		{name: "prompt render", envPrefix: envPrefixPromptRender, cfg: &t.PromptRender},
+		{name: "prompts list", envPrefix: envPrefixPromptsList, cfg: &t.PromptsList},
+		{name: "providers list", envPrefix: envPrefixProvidersList, cfg: &t.ProvidersList},
+		{name: "rate limit create", envPrefix: envPrefixRateLimitCreate, cfg: &t.RateLimitCreate},
+		{name: "rate limit delete", envPrefix: envPrefixRateLimitDelete, cfg: &t.RateLimitDelete},
+		{name: "rate limit update", envPrefix: envPrefixRateLimitUpdate, cfg: &t.RateLimitUpdate},
+		{name: "rate limits list", envPrefix: envPrefixRateLimitsList, cfg: &t.RateLimitsList},
+		{name: "trace get", envPrefix: envPrefixTraceGet, cfg: &t.TraceGet},
+		{name: "usage limit create", envPrefix: envPrefixUsageLimitCreate, cfg: &t.UsageLimitCreate},
+		{name: "usage limit delete", envPrefix: envPrefixUsageLimitDelete, cfg: &t.UsageLimitDelete},
+		{name: "usage limit update", envPrefix: envPrefixUsageLimitUpdate, cfg: &t.UsageLimitUpdate},
+		{name: "usage limits consumption", envPrefix: envPrefixUsageLimitsConsumption, cfg: &t.UsageLimitsConsumption},
+		{name: "usage limits list", envPrefix: envPrefixUsageLimitsList, cfg: &t.UsageLimitsList},
 	}
-
-	return nil
 }
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+var (
+	ErrInvalidTracingExporter  = errors.New("invalid tracing exporter")
+	ErrInvalidOTLPEndpoint     = errors.New("otlp endpoint must be an absolute url")
+	ErrInvalidBatchTimeout     = errors.New("tracing batch timeout must be positive")
+	ErrTracingFilePathRequired = errors.New("tracing file path is required by the file exporter")
+	ErrStdoutTracingWithStdio  = errors.New("the stdout tracing exporter can't be used with the stdio transport, " +
+		"which writes MCP messages to stdout")
+)
+
+// TracingExporter is where finished spans are sent.
+type TracingExporter string
+
+const (
+	// TracingExporterNone disables tracing.
+	TracingExporterNone TracingExporter = "none"
+
+	// TracingExporterOTLP sends spans to an OpenTelemetry collector with OTLP over HTTP, encoded as JSON.
+	TracingExporterOTLP TracingExporter = "otlp"
+
+	// TracingExporterFile appends spans to a file, one OTLP JSON request per line, for offline use.
+	TracingExporterFile TracingExporter = "file"
+
+	// TracingExporterStdout writes spans to stdout, one OTLP JSON request per line, for offline use.
+	TracingExporterStdout TracingExporter = "stdout"
+)
+
+// Decode implements the envconfig.Decoder interface.
+func (e *TracingExporter) Decode(value string) error {
+	val := TracingExporter(value)
+	switch val {
+	case TracingExporterNone, TracingExporterOTLP, TracingExporterFile, TracingExporterStdout:
+		*e = val
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be one of: %s, %s, %s, %s", ErrInvalidTracingExporter, value,
+			TracingExporterNone, TracingExporterOTLP, TracingExporterFile, TracingExporterStdout)
+	}
+}
+
+// Tracing configures OpenTelemetry spans for tool calls and the Portkey requests that they make.
+type Tracing struct {
+	Exporter    TracingExporter `default:"none"               envconfig:"EXPORTER"`
+	ServiceName string          `default:"portkey-mcp-server" envconfig:"SERVICE_NAME"`
+
+	// OTLPEndpoint is the base URL of the collector's OTLP/HTTP receiver. Spans are sent to its /v1/traces path.
+	OTLPEndpoint string                        `default:"http://localhost:4318" envconfig:"OTLP_ENDPOINT"`
+	OTLPHeaders  map[string]types.MaskedString `envconfig:"OTLP_HEADERS"`
+
+	FilePath string `envconfig:"FILE_PATH"`
+
+	// BatchTimeout is how long finished spans may wait before they are exported.
+	BatchTimeout time.Duration `default:"5s" envconfig:"BATCH_TIMEOUT"`
+}
+
+// Enabled reports whether spans are recorded.
+func (cfg *Tracing) Enabled() bool {
+	return cfg.Exporter != TracingExporterNone
+}
+
+func (cfg *Tracing) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.BatchTimeout <= 0 {
+		return ErrInvalidBatchTimeout
+	}
+
+	switch cfg.Exporter {
+	case TracingExporterOTLP:
+		if u, err := url.Parse(cfg.OTLPEndpoint); err != nil || !u.IsAbs() {
+			return fmt.Errorf("%w: %q", ErrInvalidOTLPEndpoint, cfg.OTLPEndpoint)
+		}
+	case TracingExporterFile:
+		if cfg.FilePath == "" {
+			return ErrTracingFilePathRequired
+		}
+	case TracingExporterNone, TracingExporterStdout:
+	}
+
+	return nil
+}
@@ -3,26 +3,60 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
-var ErrInvalidTransportType = errors.New("invalid transport type")
+var (
+	ErrInvalidTransportType  = errors.New("invalid transport type")
+	ErrNoTransports          = errors.New("at least one transport is required")
+	ErrDuplicateTransport    = errors.New("transport listed more than once")
+	ErrConflictingTransports = errors.New("transports must listen on different addresses")
+)
 
 type TransportType string
 
 const (
-	TransportStdio TransportType = "stdio"
-	TransportSSE   TransportType = "sse"
+	TransportStdio          TransportType = "stdio"
+	TransportSSE            TransportType = "sse"
+	TransportStreamableHTTP TransportType = "streamable-http"
 )
 
 // Decode implements the envconfig.Decoder interface.
 func (t *TransportType) Decode(value string) error {
 	val := TransportType(value)
 	switch val {
-	case TransportStdio, TransportSSE:
+	case TransportStdio, TransportSSE, TransportStreamableHTTP:
 		*t = val
 
 		return nil
 	default:
-		return fmt.Errorf("%w: %q, must be one of: %s, %s", ErrInvalidTransportType, value, TransportStdio, TransportSSE)
+		return fmt.Errorf("%w: %q, must be one of: %s, %s, %s",
+			ErrInvalidTransportType, value, TransportStdio, TransportSSE, TransportStreamableHTTP)
+	}
+}
+
+// TransportTypes is the comma-separated list of transports that the MCP server is served on, concurrently.
+type TransportTypes []TransportType
+
+// Has reports whether the transport is in the list.
+func (t TransportTypes) Has(transport TransportType) bool {
+	return slices.Contains(t, transport)
+}
+
+func (t TransportTypes) Validate() error {
+	if len(t) == 0 {
+		return ErrNoTransports
+	}
+
+	seen := make(map[TransportType]bool, len(t))
+
+	for _, transport := range t {
+		if seen[transport] {
+			return fmt.Errorf("%w: %s", ErrDuplicateTransport, transport)
+		}
+
+		seen[transport] = true
 	}
+
+	return nil
 }
@@ -1,5 +1,16 @@
 package config
 
+import "fmt"
+
 type SSETransport struct {
 	Address string `default:"localhost:8080" envconfig:"ADDRESS"`
+	TLS     TLS    `envconfig:"TLS"`
+}
+
+func (cfg *SSETransport) Validate() error {
+	if err := cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("error validating tls config: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEndpointPath      = errors.New("endpoint path must start with '/'")
+	ErrInvalidHeartbeatInterval = errors.New("heartbeat interval must not be negative")
+	ErrInvalidSessionIdleTTL    = errors.New("session idle ttl must not be negative")
+)
+
+type StreamableHTTPTransport struct {
+	Address string `default:"localhost:8080" envconfig:"ADDRESS"`
+
+	// EndpointPath is the single endpoint that MCP messages are sent to.
+	EndpointPath string `default:"/mcp" envconfig:"ENDPOINT_PATH"`
+
+	// Stateless doesn't assign session IDs, so every request stands on its own. Server-to-client notifications, such
+	// as resource updates, aren't delivered in stateless mode.
+	Stateless bool `default:"false" envconfig:"STATELESS"`
+
+	// Resumable keeps the messages sent on SSE streams in memory, so that clients can reconnect with Last-Event-ID and
+	// receive what they missed.
+	Resumable bool `default:"true" envconfig:"RESUMABLE"`
+
+	// HeartbeatInterval is how often a ping is sent on idle SSE streams, to keep proxies from closing them. Zero
+	// disables heartbeats.
+	HeartbeatInterval time.Duration `default:"30s" envconfig:"HEARTBEAT_INTERVAL"`
+
+	// SessionIdleTTL is how long a session may be idle before it is closed. Zero keeps sessions until the client ends
+	// them.
+	SessionIdleTTL time.Duration `default:"30m" envconfig:"SESSION_IDLE_TTL"`
+
+	TLS TLS `envconfig:"TLS"`
+}
+
+func (cfg *StreamableHTTPTransport) Validate() error {
+	if !strings.HasPrefix(cfg.EndpointPath, "/") {
+		return ErrInvalidEndpointPath
+	}
+
+	if cfg.HeartbeatInterval < 0 {
+		return ErrInvalidHeartbeatInterval
+	}
+
+	if cfg.SessionIdleTTL < 0 {
+		return ErrInvalidSessionIdleTTL
+	}
+
+	if err := cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("error validating tls config: %w", err)
+	}
+
+	return nil
+}
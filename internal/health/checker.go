@@ -0,0 +1,136 @@
+// Package health serves the endpoints that load balancers and orchestrators probe: liveness, readiness and version.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+var (
+	ErrShuttingDown       = errors.New("server is shutting down")
+	ErrPortkeyUnreachable = errors.New("portkey is unreachable")
+	ErrPortkeyRejectedKey = errors.New("portkey rejected the api key")
+	ErrPortkeyUnhealthy   = errors.New("portkey reported an error")
+	ErrCircuitOpen        = errors.New("portkey circuit breaker is open")
+)
+
+// Checker decides whether the server is ready to serve tool calls. The config has been validated by the time a
+// Checker exists, so readiness comes down to the server not shutting down, and a recent Portkey call with the server's
+// API key having succeeded, and no circuit breaker of Portkey's host being open.
+type Checker struct {
+	cfg        config.Admin
+	portkey    config.Portkey
+	httpClient *http.Client
+	breakers   *breaker.Breakers
+	host       string
+
+	shuttingDown atomic.Bool
+
+	// mu guards the cached result of the last Portkey call, and makes concurrent probes share a single call.
+	mu        sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+}
+
+// NewChecker returns a Checker that calls Portkey with the given config, using httpClient, and watches the breakers of
+// its host.
+func NewChecker(
+	cfg config.Admin,
+	portkey config.Portkey,
+	httpClient *http.Client,
+	breakers *breaker.Breakers,
+) *Checker {
+	var host string
+
+	if baseURL, err := url.Parse(portkey.BaseURL); err == nil {
+		host = baseURL.Host
+	}
+
+	return &Checker{ //nolint:exhaustruct
+		cfg:        cfg,
+		portkey:    portkey,
+		httpClient: httpClient,
+		breakers:   breakers,
+		host:       host,
+	}
+}
+
+// ShutDown makes every later readiness check fail, so that load balancers stop routing new clients to the server
+// while it shuts down gracefully.
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready returns nil if the server is ready to serve tool calls, or the reason it isn't.
+func (c *Checker) Ready(ctx context.Context) error {
+	if c.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
+
+	// Breakers change state more often than the cached result is refreshed, and are cheap to check.
+	if open := c.breakers.Open(c.host); len(open) > 0 {
+		return fmt.Errorf("%w: %s", ErrCircuitOpen, strings.Join(open, ", "))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.cfg.ReadinessCacheTTL {
+		return c.lastErr
+	}
+
+	c.lastErr = c.checkPortkey(ctx)
+	c.checkedAt = time.Now()
+
+	return c.lastErr
+}
+
+// checkPortkey makes the cheapest authenticated Portkey call there is: listing a single prompt. Any response other
+// than an authentication failure or a server error means that Portkey is up and accepts the key. Without a server key,
+// as in client-required mode, there is nothing to authenticate with, so only the server's own state is checked.
+func (c *Checker) checkPortkey(ctx context.Context) error {
+	if c.portkey.APIKey == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ReadinessTimeout)
+	defer cancel()
+
+	values := url.Values{}
+	values.Add("page_size", "1")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.portkey.BaseURL+"/prompts?"+values.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %w", err)
+	}
+
+	httpReq.Header.Set("X-Portkey-Api-Key", string(c.portkey.APIKey))
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPortkeyUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: status %d", ErrPortkeyRejectedKey, resp.StatusCode)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: status %d", ErrPortkeyUnhealthy, resp.StatusCode)
+	default:
+		return nil
+	}
+}
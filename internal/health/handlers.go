@@ -0,0 +1,57 @@
+package health
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Version is the body of the /version endpoint.
+type Version struct {
+	Version   string   `json:"version"`
+	GoVersion string   `json:"go_version"`
+	Tools     []string `json:"tools"`
+}
+
+// Handle serves /healthz, /readyz and /version on mux. The version endpoint lists the tools registered with
+// mcpServer at the time of the request.
+func Handle(mux *http.ServeMux, checker *Checker, appVersion string, mcpServer *server.MCPServer) {
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := checker.Ready(r.Context()); err != nil {
+			slog.Warn("readiness check failed", "error", err)
+			http.Error(w, "not ready: "+err.Error(), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, _ *http.Request) {
+		tools := make([]string, 0)
+		for name := range mcpServer.ListTools() {
+			tools = append(tools, name)
+		}
+
+		slices.Sort(tools)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(Version{
+			Version:   appVersion,
+			GoVersion: runtime.Version(),
+			Tools:     tools,
+		})
+		if err != nil {
+			slog.Warn("failed to write version response", "error", err)
+		}
+	})
+}
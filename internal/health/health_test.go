@@ -0,0 +1,115 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+)
+
+// newChecker returns a checker of a Portkey that responds with status, whose requests go through a breaker configured
+// by breakerCfg.
+func newChecker(t *testing.T, status int, calls *atomic.Int32, breakerCfg config.Breaker) *health.Checker {
+	t.Helper()
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if r.Header.Get("X-Portkey-Api-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(portkey.Close)
+
+	breakers := breaker.New()
+
+	httpClient := portkey.Client()
+	httpClient.Transport = breakers.Transport(breakerCfg, httpClient.Transport)
+
+	return health.NewChecker(
+		config.Admin{Address: "", ReadinessCacheTTL: time.Minute, ReadinessTimeout: time.Second},
+		config.Portkey{APIKey: "test-key", BaseURL: portkey.URL}, //nolint:exhaustruct
+		httpClient,
+		breakers,
+	)
+}
+
+func TestReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "portkey accepts the key", status: http.StatusOK, wantErr: nil},
+		{name: "portkey rejects the request itself", status: http.StatusBadRequest, wantErr: nil},
+		{name: "portkey rejects the key", status: http.StatusForbidden, wantErr: health.ErrPortkeyRejectedKey},
+		{name: "portkey is down", status: http.StatusBadGateway, wantErr: health.ErrPortkeyUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			checker := newChecker(t, tt.status, &calls, config.Breaker{}) //nolint:exhaustruct
+
+			for range 3 {
+				if err := checker.Ready(context.Background()); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+			}
+
+			if calls.Load() != 1 {
+				t.Errorf("expected the result of a single portkey call to be cached, got %d calls", calls.Load())
+			}
+		})
+	}
+}
+
+func TestReadyFailsWhileCircuitBreakerIsOpen(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := newChecker(t, http.StatusBadGateway, &calls, config.Breaker{
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+		HalfOpenProbes:   1,
+		PerEndpoint:      false,
+	})
+
+	if err := checker.Ready(context.Background()); !errors.Is(err, health.ErrPortkeyUnhealthy) {
+		t.Fatalf("expected %v, got %v", health.ErrPortkeyUnhealthy, err)
+	}
+
+	if err := checker.Ready(context.Background()); !errors.Is(err, health.ErrCircuitOpen) {
+		t.Errorf("expected %v once the failure opened the breaker, got %v", health.ErrCircuitOpen, err)
+	}
+}
+
+func TestReadyFailsDuringShutdown(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	checker := newChecker(t, http.StatusOK, &calls, config.Breaker{}) //nolint:exhaustruct
+	checker.ShutDown()
+
+	if err := checker.Ready(context.Background()); !errors.Is(err, health.ErrShuttingDown) {
+		t.Errorf("expected %v, got %v", health.ErrShuttingDown, err)
+	}
+}
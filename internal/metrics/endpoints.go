@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"slices"
+	"strings"
+)
+
+// otherEndpoint labels upstream requests whose path matches none of the endpoint templates.
+const otherEndpoint = "other"
+
+// endpointTemplates are the Portkey API endpoints that this server calls, relative to the base URL. Segments in braces
+// stand for IDs and slugs, so that upstream metrics are labeled by endpoint rather than by resource.
+//
+//nolint:gochecknoglobals
+var endpointTemplates = [][]string{
+	{"collections", "{id}"},
+	{"configs", "{id}"},
+	{"feedback"},
+	{"feedback", "{id}"},
+	{"guardrails"},
+	{"guardrails", "{id}"},
+	{"integrations"},
+	{"integrations", "{id}"},
+	{"integrations", "{id}", "models"},
+	{"integrations", "{id}", "workspaces"},
+	{"logs"},
+	{"logs", "exports"},
+	{"logs", "exports", "{id}"},
+	{"logs", "exports", "{id}", "download"},
+	{"logs", "exports", "{id}", "start"},
+	{"policies", "rate-limits"},
+	{"policies", "rate-limits", "{id}"},
+	{"policies", "usage-limits"},
+	{"policies", "usage-limits", "{id}"},
+	{"prompts"},
+	{"prompts", "{id}"},
+	{"prompts", "{id}", "render"},
+	{"prompts", "{id}", "versions", "{version}"},
+	{"providers"},
+}
+
+// analyticsResource is the only resource whose endpoints are labeled by their full path, since it holds no IDs.
+const analyticsResource = "analytics"
+
+// EndpointTemplate returns the endpoint template that an upstream request path matches, e.g. "/prompts/{id}/render"
+// for "/v1/prompts/my-prompt@12/render". The base URL's own path, like "/v1", is skipped by starting at the first
+// segment that names a Portkey resource.
+func EndpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	start := slices.IndexFunc(segments, func(segment string) bool {
+		return segment == analyticsResource || slices.ContainsFunc(endpointTemplates, func(template []string) bool {
+			return template[0] == segment
+		})
+	})
+	if start < 0 {
+		return otherEndpoint
+	}
+
+	segments = segments[start:]
+
+	if segments[0] == analyticsResource {
+		return "/" + strings.Join(segments, "/")
+	}
+
+	for _, template := range endpointTemplates {
+		if matches(template, segments) {
+			return "/" + strings.Join(template, "/")
+		}
+	}
+
+	return otherEndpoint
+}
+
+func matches(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+
+	for i, segment := range template {
+		if !strings.HasPrefix(segment, "{") && segment != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+// Package metrics records how tools are used and how Portkey responds, and exposes them in the Prometheus text
+// exposition format.
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "portkey_mcp_"
+
+// Kinds of tool call errors.
+const (
+	// ErrorKindResult is a call whose result was an error, like invalid input or a failed Portkey request.
+	ErrorKindResult = "result"
+
+	// ErrorKindGo is a call whose handler returned a Go error, which the client sees as a JSON-RPC error.
+	ErrorKindGo = "go"
+)
+
+// upstreamStatusError labels upstream requests that got no response at all, like connection failures and timeouts.
+const upstreamStatusError = "error"
+
+// durationBuckets are the upper bounds of latency histograms, in seconds. They reach the default Portkey client
+// timeout.
+//
+//nolint:gochecknoglobals
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics are the metrics of tool calls and of the upstream Portkey requests that they make.
+type Metrics struct {
+	registry *Registry
+
+	toolCalls        *Counter
+	toolCallErrors   *Counter
+	toolCallDuration *Histogram
+
+	upstreamRequests        *Counter
+	upstreamRequestDuration *Histogram
+}
+
+func New() *Metrics {
+	registry := NewRegistry()
+
+	return &Metrics{
+		registry: registry,
+		toolCalls: registry.NewCounter(namespace+"tool_calls_total",
+			"Tool calls, by tool.", "tool"),
+		toolCallErrors: registry.NewCounter(namespace+"tool_call_errors_total",
+			"Tool calls that failed, by tool and kind: 'result' for error results, 'go' for handler errors.",
+			"tool", "kind"),
+		toolCallDuration: registry.NewHistogram(namespace+"tool_call_duration_seconds",
+			"Duration of tool calls, by tool.", durationBuckets, "tool"),
+		upstreamRequests: registry.NewCounter(namespace+"upstream_requests_total",
+			"Requests to Portkey, by host, method, endpoint template and status code, or 'error' without a response.",
+			"host", "method", "endpoint", "status"),
+		upstreamRequestDuration: registry.NewHistogram(namespace+"upstream_request_duration_seconds",
+			"Time until Portkey responded with headers, by host, method and endpoint template.", durationBuckets,
+			"host", "method", "endpoint"),
+	}
+}
+
+// ObserveToolCall records a finished tool call. isError is whether the call's result was an error, and err is the
+// error returned by its handler.
+func (m *Metrics) ObserveToolCall(tool string, duration time.Duration, isError bool, err error) {
+	m.toolCalls.Inc(tool)
+	m.toolCallDuration.Observe(duration.Seconds(), tool)
+
+	switch {
+	case err != nil:
+		m.toolCallErrors.Inc(tool, ErrorKindGo)
+	case isError:
+		m.toolCallErrors.Inc(tool, ErrorKindResult)
+	}
+}
+
+// Transport returns an http.RoundTripper that records the requests made through next, which is
+// http.DefaultTransport if nil.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{metrics: m, next: next}
+}
+
+// Handler serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		if err := m.registry.WriteText(w); err != nil {
+			slog.Warn("failed to write metrics response", "error", err)
+		}
+	})
+}
+
+type transport struct {
+	metrics *Metrics
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	endpoint := EndpointTemplate(req.URL.EscapedPath())
+
+	status := upstreamStatusError
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	t.metrics.upstreamRequests.Inc(req.URL.Host, req.Method, endpoint, status)
+	t.metrics.upstreamRequestDuration.Observe(time.Since(start).Seconds(), req.URL.Host, req.Method, endpoint)
+
+	return resp, err //nolint:wrapcheck
+}
@@ -0,0 +1,89 @@
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/v1/prompts", want: "/prompts"},
+		{path: "/v1/prompts/my-prompt%4012/render", want: "/prompts/{id}/render"},
+		{path: "/gateway/v1/prompts/my-prompt/versions/3", want: "/prompts/{id}/versions/{version}"},
+		{path: "/v1/logs/exports/export-1/download", want: "/logs/exports/{id}/download"},
+		{path: "/v1/policies/rate-limits/policy-1", want: "/policies/rate-limits/{id}"},
+		{path: "/v1/analytics/graphs/cache/hit-rate", want: "/analytics/graphs/cache/hit-rate"},
+		{path: "/v1/prompts/my-prompt/unknown", want: "other"},
+		{path: "/v1/chat/completions", want: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			if got := metrics.EndpointTemplate(tt.path); got != tt.want {
+				t.Errorf("EndpointTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerExposesRecordedMetrics(t *testing.T) {
+	t.Parallel()
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer portkey.Close()
+
+	m := metrics.New()
+
+	m.ObserveToolCall("prompt_render", 20*time.Millisecond, false, nil)
+	m.ObserveToolCall("prompt_render", 2*time.Second, true, nil)
+	m.ObserveToolCall("prompts_list", time.Millisecond, false, errors.New("boom"))
+
+	client := &http.Client{Transport: m.Transport(nil)} //nolint:exhaustruct
+
+	resp, err := client.Get(portkey.URL + "/v1/prompts/my-prompt/render")
+	if err != nil {
+		t.Fatalf("failed to call portkey: %v", err)
+	}
+
+	resp.Body.Close()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	host := strings.TrimPrefix(portkey.URL, "http://")
+	upstreamLabels := `host="` + host + `",method="GET",endpoint="/prompts/{id}/render"`
+
+	for _, want := range []string{
+		"# TYPE portkey_mcp_tool_calls_total counter",
+		`portkey_mcp_tool_calls_total{tool="prompt_render"} 2`,
+		`portkey_mcp_tool_call_errors_total{tool="prompt_render",kind="result"} 1`,
+		`portkey_mcp_tool_call_errors_total{tool="prompts_list",kind="go"} 1`,
+		"# TYPE portkey_mcp_tool_call_duration_seconds histogram",
+		`portkey_mcp_tool_call_duration_seconds_bucket{tool="prompt_render",le="0.025"} 1`,
+		`portkey_mcp_tool_call_duration_seconds_bucket{tool="prompt_render",le="2.5"} 2`,
+		`portkey_mcp_tool_call_duration_seconds_bucket{tool="prompt_render",le="+Inf"} 2`,
+		`portkey_mcp_tool_call_duration_seconds_count{tool="prompt_render"} 2`,
+		`portkey_mcp_upstream_requests_total{` + upstreamLabels + `,status="503"} 1`,
+		`portkey_mcp_upstream_request_duration_seconds_count{` + upstreamLabels + `} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
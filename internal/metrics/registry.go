@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelSeparator joins label values into the key of a series. It can't appear in label values, which are tool names,
+// HTTP methods, hosts, endpoint templates and status codes.
+const labelSeparator = "\xff"
+
+// collector is a metric family that can write itself in the Prometheus text exposition format.
+type collector interface {
+	write(b *strings.Builder)
+}
+
+// Registry holds metric families, and writes them in the Prometheus text exposition format. Only counters and
+// histograms are supported, since they are all that this server records.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{} //nolint:exhaustruct
+}
+
+// NewCounter registers a counter family with the given label names.
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	counter := &Counter{
+		family: newFamily(name, help, labelNames),
+		values: make(map[string]float64),
+	}
+
+	r.register(counter)
+
+	return counter
+}
+
+// NewHistogram registers a histogram family with the given upper bounds of its buckets, in increasing order, and
+// label names.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	histogram := &Histogram{
+		family:  newFamily(name, help, labelNames),
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+
+	r.register(histogram)
+
+	return histogram
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteText writes every metric family in the Prometheus text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	collectors := slices.Clone(r.collectors)
+	r.mu.Unlock()
+
+	var b strings.Builder
+	for _, c := range collectors {
+		c.write(&b)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	return nil
+}
+
+type family struct {
+	name       string
+	help       string
+	labelNames []string
+}
+
+func newFamily(name, help string, labelNames []string) family {
+	return family{name: name, help: help, labelNames: labelNames}
+}
+
+func (f *family) key(labelValues []string) string {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values", f.name, len(f.labelNames), len(labelValues)))
+	}
+
+	return strings.Join(labelValues, labelSeparator)
+}
+
+func (f *family) writeHeader(b *strings.Builder, metricType string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, metricType)
+}
+
+// labels formats the labels of a series, plus any extra name and value pairs, e.g. `{tool="x",le="0.5"}`.
+func (f *family) labels(key string, extra ...string) string {
+	var pairs []string
+
+	if len(f.labelNames) > 0 {
+		for i, value := range strings.Split(key, labelSeparator) {
+			pairs = append(pairs, f.labelNames[i]+`="`+escapeLabelValue(value)+`"`)
+		}
+	}
+
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabelValue(extra[i+1])+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a family of counters, one per combination of label values.
+type Counter struct {
+	family
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// Inc increments the counter with the given label values, in the order of the family's label names.
+func (c *Counter) Inc(labelValues ...string) {
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key]++
+}
+
+func (c *Counter) write(b *strings.Builder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(b, "counter")
+
+	for _, key := range slices.Sorted(maps.Keys(c.values)) {
+		fmt.Fprintf(b, "%s%s %s\n", c.name, c.labels(key), formatFloat(c.values[key]))
+	}
+}
+
+// Histogram is a family of histograms, one per combination of label values.
+type Histogram struct {
+	family
+
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	// counts holds the number of observations in each bucket, not cumulatively.
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe records a value in the histogram with the given label values, in the order of the family's label names.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets)), count: 0, sum: 0}
+		h.series[key] = series
+	}
+
+	if i, _ := slices.BinarySearch(h.buckets, value); i < len(h.buckets) {
+		series.counts[i]++
+	}
+
+	series.count++
+	series.sum += value
+}
+
+func (h *Histogram) write(b *strings.Builder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(b, "histogram")
+
+	for _, key := range slices.Sorted(maps.Keys(h.series)) {
+		series := h.series[key]
+
+		var cumulative uint64
+
+		for i, upperBound := range h.buckets {
+			cumulative += series.counts[i]
+
+			fmt.Fprintf(b, "%s_bucket%s %d\n", h.name, h.labels(key, "le", formatFloat(upperBound)), cumulative)
+		}
+
+		fmt.Fprintf(b, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.labels(key, "le", "+Inf"), series.count,
+			h.name, h.labels(key), formatFloat(series.sum),
+			h.name, h.labels(key), series.count)
+	}
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
@@ -0,0 +1,88 @@
+package prompts
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptrender"
+)
+
+// variablePattern matches Mustache variable tags like {{name}} or {{{name}}}, but not sections, inverted sections,
+// comments or partials, which start with #, ^, /, ! or >.
+//
+//nolint:gochecknoglobals
+var variablePattern = regexp.MustCompile(`{{{?\s*([^#^/!>{}\s][^{}]*?)\s*}?}}`)
+
+// PromptArguments derives the MCP prompt arguments of a Portkey prompt. Variables used in the template are required,
+// in the order they first appear. Variables only declared in the prompt's parameters are optional, and listed after.
+func PromptArguments(template string, parameters map[string]any) []mcp.PromptArgument {
+	var arguments []mcp.PromptArgument
+
+	seen := make(map[string]bool)
+
+	for _, match := range variablePattern.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		arguments = append(arguments, mcp.PromptArgument{
+			Name:        name,
+			Title:       "",
+			Description: argumentDescription(parameters[name]),
+			Required:    true,
+		})
+	}
+
+	var optional []string
+
+	for name := range parameters {
+		if !seen[name] {
+			optional = append(optional, name)
+		}
+	}
+
+	slices.Sort(optional)
+
+	for _, name := range optional {
+		arguments = append(arguments, mcp.PromptArgument{
+			Name:        name,
+			Title:       "",
+			Description: argumentDescription(parameters[name]),
+			Required:    false,
+		})
+	}
+
+	return arguments
+}
+
+// argumentDescription describes an argument using the type declared for it in the prompt's parameters, if any.
+func argumentDescription(declared any) string {
+	if declaredType, ok := declared.(string); ok && declaredType != "" {
+		return fmt.Sprintf("Template variable of type %s.", declaredType)
+	}
+
+	return "Template variable."
+}
+
+// PromptMessages converts rendered Portkey messages to MCP prompt messages. MCP only has user and assistant roles, so
+// system and developer messages are sent with the user role, which keeps their instructions in the conversation.
+func PromptMessages(messages []promptrender.Message) []mcp.PromptMessage {
+	promptMessages := make([]mcp.PromptMessage, 0, len(messages))
+
+	for _, message := range messages {
+		role := mcp.RoleUser
+		if message.Role == string(mcp.RoleAssistant) {
+			role = mcp.RoleAssistant
+		}
+
+		promptMessages = append(promptMessages, mcp.NewPromptMessage(role, mcp.NewTextContent(message.Content)))
+	}
+
+	return promptMessages
+}
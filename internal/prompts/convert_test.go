@@ -0,0 +1,62 @@
+package prompts_test
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptrender"
+)
+
+func TestPromptArguments(t *testing.T) {
+	t.Parallel()
+
+	template := "{{#system}}You are {{ persona }}.{{/system}}{{#user}}Hi {{name}}, {{{question}}} {{name}}{{/user}}"
+	parameters := map[string]any{"name": "string", "tone": "string"}
+
+	arguments := prompts.PromptArguments(template, parameters)
+
+	want := []struct {
+		name     string
+		required bool
+	}{
+		{"persona", true},
+		{"name", true},
+		{"question", true},
+		{"tone", false},
+	}
+
+	if len(arguments) != len(want) {
+		t.Fatalf("expected %d arguments, got %+v", len(want), arguments)
+	}
+
+	for i, w := range want {
+		if arguments[i].Name != w.name || arguments[i].Required != w.required {
+			t.Errorf("argument %d: expected %s (required: %t), got %+v", i, w.name, w.required, arguments[i])
+		}
+	}
+}
+
+func TestPromptMessages(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	messages := prompts.PromptMessages([]promptrender.Message{
+		{Role: "system", Content: "Be brief."},
+		{Role: "user", Content: "Hi"},
+		{Role: "assistant", Content: "Hello!"},
+	})
+
+	wantRoles := []mcp.Role{mcp.RoleUser, mcp.RoleUser, mcp.RoleAssistant}
+
+	for i, role := range wantRoles {
+		if messages[i].Role != role {
+			t.Errorf("message %d: expected role %s, got %s", i, role, messages[i].Role)
+		}
+	}
+
+	if text, ok := messages[0].Content.(mcp.TextContent); !ok || text.Text != "Be brief." {
+		t.Errorf("unexpected content: %+v", messages[0].Content)
+	}
+}
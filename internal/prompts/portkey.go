@@ -0,0 +1,129 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+const (
+	// Portkey API query parameters.
+	apiParamCollectionID = "collection_id"
+	apiParamWorkspaceID  = "workspace_id"
+	apiParamCurrentPage  = "current_page"
+	apiParamPageSize     = "page_size"
+
+	// pageSize and maxPages bound how many prompts are listed from each collection.
+	pageSize = 100
+	maxPages = 10
+)
+
+// Prompt represents the response from the Portkey Retrieve Prompt API.
+type Prompt struct {
+	ID            string         `json:"id"`
+	Slug          string         `json:"slug"`
+	Name          string         `json:"name"`
+	CollectionID  string         `json:"collection_id"`
+	String        string         `json:"string"`
+	Parameters    map[string]any `json:"parameters"`
+	Model         string         `json:"model"`
+	LastUpdatedAt time.Time      `json:"last_updated_at"`
+}
+
+// List lists the prompts in the given collections, or in all collections if none are given, optionally limited to a
+// workspace.
+func List(
+	ctx context.Context,
+	portkey config.Portkey,
+	collectionIDs []string,
+	workspaceID string,
+) ([]promptslist.PromptData, error) {
+	if len(collectionIDs) == 0 {
+		collectionIDs = []string{""}
+	}
+
+	var prompts []promptslist.PromptData
+
+	for _, collectionID := range collectionIDs {
+		collectionPrompts, err := listCollectionPrompts(ctx, portkey, collectionID, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		prompts = append(prompts, collectionPrompts...)
+	}
+
+	return prompts, nil
+}
+
+// listCollectionPrompts pages through the Portkey List Prompts API, up to maxPages.
+func listCollectionPrompts(
+	ctx context.Context,
+	portkey config.Portkey,
+	collectionID string,
+	workspaceID string,
+) ([]promptslist.PromptData, error) {
+	var prompts []promptslist.PromptData
+
+	for page := 1; page <= maxPages; page++ {
+		values := url.Values{}
+		values.Add(apiParamCurrentPage, strconv.Itoa(page))
+		values.Add(apiParamPageSize, strconv.Itoa(pageSize))
+
+		if collectionID != "" {
+			values.Add(apiParamCollectionID, collectionID)
+		}
+
+		if workspaceID != "" {
+			values.Add(apiParamWorkspaceID, workspaceID)
+		}
+
+		var portkeyResp promptslist.Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    portkey.BaseURL + "/prompts?" + values.Encode(),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return nil, tools.ResultError(errResult)
+		}
+
+		prompts = append(prompts, portkeyResp.Data...)
+
+		if len(portkeyResp.Data) < pageSize || len(prompts) >= portkeyResp.Total {
+			return prompts, nil
+		}
+	}
+
+	middleware.GetLogger(ctx).Warn("too many prompts to expose, only exposing the first pages",
+		"collection_id", collectionID,
+		"prompt_count", len(prompts),
+	)
+
+	return prompts, nil
+}
+
+// Get calls the Portkey Retrieve Prompt API.
+func Get(ctx context.Context, portkey config.Portkey, slug string) (Prompt, error) {
+	var prompt Prompt
+
+	errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s/prompts/%s", portkey.BaseURL, url.PathEscape(slug)),
+		Body:   nil,
+	}, &prompt)
+	if errResult != nil {
+		return Prompt{}, tools.ResultError(errResult)
+	}
+
+	return prompt, nil
+}
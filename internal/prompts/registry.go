@@ -0,0 +1,211 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/retry"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptrender"
+)
+
+var ErrRenderFailed = errors.New("portkey reported a failure while rendering the prompt")
+
+// Registry exposes Portkey prompts as native MCP prompts, and keeps them in sync with Portkey. Adding, changing or
+// removing prompts makes the MCP server send a prompts/list_changed notification to connected clients.
+type Registry struct {
+	portkey    config.Portkey
+	cfg        config.Prompts
+	mcpServer  *server.MCPServer
+	httpClient *http.Client
+
+	mu sync.Mutex
+
+	// registered holds when each exposed prompt was last updated, keyed by slug, so that unchanged prompts aren't
+	// retrieved or re-registered on every refresh.
+	registered map[string]time.Time
+}
+
+func NewRegistry(
+	portkey config.Portkey,
+	cfg config.Prompts,
+	mcpServer *server.MCPServer,
+	httpClient *http.Client,
+) *Registry {
+	return &Registry{
+		portkey:    portkey,
+		cfg:        cfg,
+		mcpServer:  mcpServer,
+		httpClient: httpClient,
+		mu:         sync.Mutex{},
+		registered: make(map[string]time.Time),
+	}
+}
+
+// Run loads the prompts, then refreshes them every refresh interval until the context is cancelled. Failed refreshes
+// are logged and keep the previously exposed prompts.
+func (r *Registry) Run(ctx context.Context) {
+	if err := r.Refresh(ctx); err != nil {
+		slog.Warn("failed to load portkey prompts", "error", err)
+	}
+
+	if r.cfg.RefreshInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				slog.Warn("failed to refresh portkey prompts", "error", err)
+			}
+		}
+	}
+}
+
+// Refresh lists the prompts from Portkey and registers, updates or removes MCP prompts to match.
+func (r *Registry) Refresh(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx = middleware.ContextWithHTTPClient(ctx, r.httpClient)
+
+	entries, err := List(ctx, r.portkey, r.cfg.CollectionIDs, r.cfg.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	registered := make(map[string]time.Time, len(entries))
+
+	var changed []server.ServerPrompt
+
+	for _, entry := range entries {
+		lastUpdatedAt, exists := r.registered[entry.Slug]
+		if exists && lastUpdatedAt.Equal(entry.LastUpdatedAt) {
+			registered[entry.Slug] = lastUpdatedAt
+
+			continue
+		}
+
+		prompt, err := Get(ctx, r.portkey, entry.Slug)
+		if err != nil {
+			slog.Warn("failed to retrieve portkey prompt, skipping it", "prompt_slug", entry.Slug, "error", err)
+
+			if exists {
+				registered[entry.Slug] = lastUpdatedAt
+			}
+
+			continue
+		}
+
+		registered[entry.Slug] = entry.LastUpdatedAt
+		changed = append(changed, r.serverPrompt(prompt))
+	}
+
+	var removed []string
+
+	for slug := range r.registered {
+		if _, ok := registered[slug]; !ok {
+			removed = append(removed, slug)
+		}
+	}
+
+	if len(removed) > 0 {
+		r.mcpServer.DeletePrompts(removed...)
+	}
+
+	if len(changed) > 0 {
+		r.mcpServer.AddPrompts(changed...)
+	}
+
+	r.registered = registered
+
+	if len(changed) > 0 || len(removed) > 0 {
+		slog.Info("refreshed portkey prompts",
+			"prompt_count", len(registered),
+			"changed_count", len(changed),
+			"removed_count", len(removed),
+		)
+	}
+
+	return nil
+}
+
+func (r *Registry) serverPrompt(prompt Prompt) server.ServerPrompt {
+	description := prompt.Name
+	if prompt.Model != "" {
+		description = fmt.Sprintf("%s (Portkey prompt for %s)", prompt.Name, prompt.Model)
+	}
+
+	mcpPrompt := mcp.NewPrompt(prompt.Slug, mcp.WithPromptDescription(description))
+	if prompt.Name != "" {
+		mcpPrompt.Title = prompt.Name
+	}
+
+	mcpPrompt.Arguments = PromptArguments(prompt.String, prompt.Parameters)
+
+	return server.ServerPrompt{
+		Prompt:  mcpPrompt,
+		Handler: r.promptHandler(prompt.Slug, description),
+	}
+}
+
+// promptHandler calls the Portkey Prompt Render API with the prompt arguments, and returns the rendered messages.
+func (r *Registry) promptHandler(slug, description string) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		lgr := middleware.GetLogger(ctx).With("prompt_slug", slug)
+
+		ctx = middleware.ContextWithLogger(ctx, lgr)
+		ctx = middleware.ContextWithHTTPClient(ctx, r.httpClient)
+
+		lgr.Debug("processing get prompt request")
+
+		variables := request.Params.Arguments
+		if variables == nil {
+			// The API expects variables key even if empty.
+			variables = map[string]string{}
+		}
+
+		var portkeyResp promptrender.Response
+
+		// Rendering changes nothing, so it is retried like a GET, unless the operator turned that off.
+		reqCtx := ctx
+		if r.portkey.Client.Retry.AllowRender {
+			reqCtx = retry.Allow(ctx)
+		}
+
+		//nolint:exhaustruct
+		errResult := tools.CallPortkeyAPI(reqCtx, r.portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    fmt.Sprintf("%s/prompts/%s/render", r.portkey.BaseURL, url.PathEscape(slug)),
+			Body:   promptrender.Request{Variables: variables},
+		}, &portkeyResp)
+		if errResult != nil {
+			return nil, tools.ResultError(errResult)
+		}
+
+		if !portkeyResp.Success {
+			lgr.Error("portkey api returned success:false")
+
+			return nil, ErrRenderFailed
+		}
+
+		return mcp.NewGetPromptResult(description, PromptMessages(portkeyResp.Data.Messages)), nil
+	}
+}
@@ -0,0 +1,209 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+// Catalog exposes Portkey prompts, collections and configs as MCP resources. Resource templates make any of them
+// readable by URI, while each prompt is also listed as a concrete resource so clients can browse them.
+type Catalog struct {
+	portkey    config.Portkey
+	cfg        config.Resources
+	mcpServer  *server.MCPServer
+	httpClient *http.Client
+
+	mu sync.Mutex
+
+	// listed holds the URIs of the prompts currently listed as concrete resources.
+	listed map[string]bool
+}
+
+func NewCatalog(
+	portkey config.Portkey,
+	cfg config.Resources,
+	mcpServer *server.MCPServer,
+	httpClient *http.Client,
+) *Catalog {
+	return &Catalog{
+		portkey:    portkey,
+		cfg:        cfg,
+		mcpServer:  mcpServer,
+		httpClient: httpClient,
+		mu:         sync.Mutex{},
+		listed:     make(map[string]bool),
+	}
+}
+
+// RegisterTemplates registers the resource templates for prompts, collections and configs.
+func (c *Catalog) RegisterTemplates() {
+	c.mcpServer.AddResourceTemplates(
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(PromptURITemplate, "Portkey prompt",
+				mcp.WithTemplateDescription("The published version of a Portkey prompt, as JSON."),
+				mcp.WithTemplateMIMEType(mimeTypeJSON),
+			),
+			Handler: c.promptHandler(),
+		},
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(PromptVersionURITemplate, "Portkey prompt version",
+				mcp.WithTemplateDescription("A specific version of a Portkey prompt, as JSON."),
+				mcp.WithTemplateMIMEType(mimeTypeJSON),
+			),
+			Handler: c.promptHandler(),
+		},
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(PromptTemplateURITemplate, "Portkey prompt template",
+				mcp.WithTemplateDescription("The raw template of the published version of a Portkey prompt."),
+				mcp.WithTemplateMIMEType(mimeTypeText),
+			),
+			Handler: c.promptTemplateHandler(),
+		},
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(PromptVersionTemplateURITemplate, "Portkey prompt version template",
+				mcp.WithTemplateDescription("The raw template of a specific version of a Portkey prompt."),
+				mcp.WithTemplateMIMEType(mimeTypeText),
+			),
+			Handler: c.promptTemplateHandler(),
+		},
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(CollectionURITemplate, "Portkey collection",
+				mcp.WithTemplateDescription("A Portkey prompt collection and the prompts in it, as JSON."),
+				mcp.WithTemplateMIMEType(mimeTypeJSON),
+			),
+			Handler: c.collectionHandler(),
+		},
+		server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(ConfigURITemplate, "Portkey config",
+				mcp.WithTemplateDescription("A Portkey config, as JSON."),
+				mcp.WithTemplateMIMEType(mimeTypeJSON),
+			),
+			Handler: c.configHandler(),
+		},
+	)
+}
+
+// Run lists the prompts, then refreshes them every refresh interval until the context is cancelled. Failed refreshes
+// are logged and keep the previously listed prompts.
+func (c *Catalog) Run(ctx context.Context) {
+	if err := c.Refresh(ctx); err != nil {
+		slog.Warn("failed to list portkey prompts as resources", "error", err)
+	}
+
+	if c.cfg.RefreshInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				slog.Warn("failed to refresh portkey prompt resources", "error", err)
+			}
+		}
+	}
+}
+
+// Refresh lists the prompts from Portkey, and lists each of them as a concrete resource.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	ctx = middleware.ContextWithHTTPClient(ctx, c.httpClient)
+
+	entries, err := prompts.List(ctx, c.portkey, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	c.setPromptResources(entries)
+
+	return nil
+}
+
+// setPromptResources replaces the listed prompt resources with the given prompts.
+func (c *Catalog) setPromptResources(entries []promptslist.PromptData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listed := make(map[string]bool, len(entries))
+	resources := make([]server.ServerResource, 0, len(entries))
+
+	for _, entry := range entries {
+		uri := PromptURI(entry.Slug)
+		listed[uri] = true
+
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(uri, entry.Name,
+				mcp.WithResourceDescription(fmt.Sprintf("Portkey prompt %q, as JSON.", entry.Slug)),
+				mcp.WithMIMEType(mimeTypeJSON),
+			),
+			Handler: c.promptResourceHandler(entry.Slug),
+		})
+	}
+
+	var removed []string
+
+	for uri := range c.listed {
+		if !listed[uri] {
+			removed = append(removed, uri)
+		}
+	}
+
+	if len(removed) > 0 {
+		c.mcpServer.DeleteResources(removed...)
+	}
+
+	if len(resources) > 0 {
+		c.mcpServer.AddResources(resources...)
+	}
+
+	c.listed = listed
+
+	slog.Debug("listed portkey prompts as resources", "prompt_count", len(listed), "removed_count", len(removed))
+}
+
+// promptResourceHandler reads a listed prompt. Concrete resources aren't matched against templates, so the slug is
+// passed to the template handler directly.
+func (c *Catalog) promptResourceHandler(slug string) server.ResourceHandlerFunc {
+	handler := c.promptHandler()
+
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		request.Params.Arguments = map[string]any{"slug": slug}
+
+		return handler(ctx, request)
+	}
+}
+
+// withContext adds the HTTP client and a request-scoped logger to the context of a resource handler.
+func (c *Catalog) withContext(handler server.ResourceTemplateHandlerFunc) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		lgr := middleware.GetLogger(ctx).With("resource_uri", request.Params.URI)
+
+		ctx = middleware.ContextWithLogger(ctx, lgr)
+		ctx = middleware.ContextWithHTTPClient(ctx, c.httpClient)
+
+		lgr.Debug("processing read resource request")
+
+		contents, err := handler(ctx, request)
+		if err != nil {
+			lgr.Info("read resource request failed", "error", err)
+		}
+
+		return contents, err
+	}
+}
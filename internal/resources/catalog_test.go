@@ -0,0 +1,134 @@
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/resources"
+)
+
+func newTestServer(t *testing.T) *server.MCPServer {
+	t.Helper()
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/prompts":
+			fmt.Fprint(w, `{"total":1,"data":[{"slug":"greeting","name":"Greeting",`+
+				`"last_updated_at":"2024-01-01T00:00:00Z"}]}`)
+		case "/prompts/greeting", "/prompts/greeting/versions/3":
+			fmt.Fprint(w, `{"slug":"greeting","string":"Hello {{name}}"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(portkey.Close)
+
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(false, true))
+
+	catalog := resources.NewCatalog(
+		config.Portkey{BaseURL: portkey.URL}, //nolint:exhaustruct
+		config.Resources{Enabled: true, RefreshInterval: 0},
+		mcpServer,
+		portkey.Client(),
+	)
+	catalog.RegisterTemplates()
+
+	if err := catalog.Refresh(t.Context()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	return mcpServer
+}
+
+func readResource(t *testing.T, mcpServer *server.MCPServer, uri string) mcp.TextResourceContents {
+	t.Helper()
+
+	msg := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":%q}}`, uri)
+
+	resp, ok := mcpServer.HandleMessage(context.Background(), []byte(msg)).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("reading %s did not succeed", uri)
+	}
+
+	result, ok := resp.Result.(mcp.ReadResourceResult)
+	if !ok || len(result.Contents) != 1 {
+		t.Fatalf("unexpected result reading %s: %+v", uri, resp.Result)
+	}
+
+	contents, ok := result.Contents[0].(mcp.TextResourceContents)
+	if !ok {
+		t.Fatalf("unexpected contents reading %s: %+v", uri, result.Contents[0])
+	}
+
+	return contents
+}
+
+func TestCatalogListsPrompts(t *testing.T) {
+	t.Parallel()
+
+	mcpServer := newTestServer(t)
+
+	resp, ok := mcpServer.HandleMessage(context.Background(),
+		[]byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatal("listing resources did not succeed")
+	}
+
+	result, ok := resp.Result.(mcp.ListResourcesResult)
+	if !ok || len(result.Resources) != 1 {
+		t.Fatalf("unexpected result listing resources: %+v", resp.Result)
+	}
+
+	if got := result.Resources[0].URI; got != resources.PromptURI("greeting") {
+		t.Errorf("listed uri = %q, want %q", got, resources.PromptURI("greeting"))
+	}
+}
+
+func TestCatalogReadsPrompts(t *testing.T) {
+	t.Parallel()
+
+	mcpServer := newTestServer(t)
+
+	tests := []struct {
+		uri      string
+		mimeType string
+		text     string
+	}{
+		{uri: "portkey://prompts/greeting", mimeType: "application/json"},
+		{uri: "portkey://prompts/greeting@3", mimeType: "application/json"},
+		{uri: "portkey://prompts/greeting/template", mimeType: "text/plain", text: "Hello {{name}}"},
+		{uri: "portkey://prompts/greeting@3/template", mimeType: "text/plain", text: "Hello {{name}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			t.Parallel()
+
+			contents := readResource(t, mcpServer, tt.uri)
+
+			if contents.URI != tt.uri {
+				t.Errorf("uri = %q, want %q", contents.URI, tt.uri)
+			}
+
+			if contents.MIMEType != tt.mimeType {
+				t.Errorf("mime type = %q, want %q", contents.MIMEType, tt.mimeType)
+			}
+
+			if tt.text != "" && contents.Text != tt.text {
+				t.Errorf("text = %q, want %q", contents.Text, tt.text)
+			}
+
+			if tt.mimeType == "application/json" && !json.Valid([]byte(contents.Text)) {
+				t.Errorf("text is not valid json: %s", contents.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"slices"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+// ChangedURIs compares two listings of prompts, and returns the URIs of the resources whose content changed, sorted.
+// A prompt that was added, removed or updated changes its own resources and those of the collections it is in. Version
+// resources are immutable, so they never change.
+func ChangedURIs(previous, current []promptslist.PromptData) []string {
+	before := make(map[string]promptslist.PromptData, len(previous))
+	for _, prompt := range previous {
+		before[prompt.Slug] = prompt
+	}
+
+	changed := make(map[string]bool)
+
+	markChanged := func(prompt promptslist.PromptData) {
+		changed[PromptURI(prompt.Slug)] = true
+		changed[PromptTemplateURI(prompt.Slug)] = true
+
+		if prompt.CollectionID != "" {
+			changed[CollectionURI(prompt.CollectionID)] = true
+		}
+	}
+
+	for _, prompt := range current {
+		old, ok := before[prompt.Slug]
+		delete(before, prompt.Slug)
+
+		switch {
+		case !ok:
+			markChanged(prompt)
+		case !old.LastUpdatedAt.Equal(prompt.LastUpdatedAt) || old.CollectionID != prompt.CollectionID:
+			// A prompt moved to another collection changes both collections.
+			markChanged(old)
+			markChanged(prompt)
+		}
+	}
+
+	// Whatever is left was removed.
+	for _, prompt := range before {
+		markChanged(prompt)
+	}
+
+	uris := make([]string, 0, len(changed))
+	for uri := range changed {
+		uris = append(uris, uri)
+	}
+
+	slices.Sort(uris)
+
+	return uris
+}
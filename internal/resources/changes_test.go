@@ -0,0 +1,84 @@
+package resources_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/resources"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+func prompt(slug, collectionID string, updatedAt time.Time) promptslist.PromptData {
+	return promptslist.PromptData{ //nolint:exhaustruct
+		Slug:          slug,
+		CollectionID:  collectionID,
+		LastUpdatedAt: updatedAt,
+	}
+}
+
+func TestChangedURIs(t *testing.T) {
+	t.Parallel()
+
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		previous []promptslist.PromptData
+		current  []promptslist.PromptData
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			previous: []promptslist.PromptData{prompt("a", "c1", earlier)},
+			current:  []promptslist.PromptData{prompt("a", "c1", earlier)},
+			want:     []string{},
+		},
+		{
+			name:     "updated",
+			previous: []promptslist.PromptData{prompt("a", "c1", earlier), prompt("b", "c1", earlier)},
+			current:  []promptslist.PromptData{prompt("a", "c1", later), prompt("b", "c1", earlier)},
+			want: []string{
+				"portkey://collections/c1",
+				"portkey://prompts/a",
+				"portkey://prompts/a/template",
+			},
+		},
+		{
+			name:     "added and removed",
+			previous: []promptslist.PromptData{prompt("a", "c1", earlier)},
+			current:  []promptslist.PromptData{prompt("b", "c2", earlier)},
+			want: []string{
+				"portkey://collections/c1",
+				"portkey://collections/c2",
+				"portkey://prompts/a",
+				"portkey://prompts/a/template",
+				"portkey://prompts/b",
+				"portkey://prompts/b/template",
+			},
+		},
+		{
+			name:     "moved to another collection",
+			previous: []promptslist.PromptData{prompt("a", "c1", earlier)},
+			current:  []promptslist.PromptData{prompt("a", "c2", earlier)},
+			want: []string{
+				"portkey://collections/c1",
+				"portkey://collections/c2",
+				"portkey://prompts/a",
+				"portkey://prompts/a/template",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := resources.ChangedURIs(tt.previous, tt.current)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ChangedURIs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+)
+
+var (
+	ErrMissingURIVariable = errors.New("resource uri is missing a variable")
+	ErrNoTemplate         = errors.New("prompt has no template")
+)
+
+// promptHandler reads a prompt, or a specific version of it, as JSON.
+func (c *Catalog) promptHandler() server.ResourceTemplateHandlerFunc {
+	return c.withContext(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		slug, err := uriVariable(request, "slug")
+		if err != nil {
+			return nil, err
+		}
+
+		version, _ := uriVariable(request, "version")
+
+		prompt, err := getJSON(ctx, c.portkey, promptURL(c.portkey, slug, version))
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonContents(request.Params.URI, prompt), nil
+	})
+}
+
+// promptTemplateHandler reads the raw template of a prompt, or of a specific version of it, as plain text.
+func (c *Catalog) promptTemplateHandler() server.ResourceTemplateHandlerFunc {
+	return c.withContext(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		slug, err := uriVariable(request, "slug")
+		if err != nil {
+			return nil, err
+		}
+
+		version, _ := uriVariable(request, "version")
+
+		raw, err := getJSON(ctx, c.portkey, promptURL(c.portkey, slug, version))
+		if err != nil {
+			return nil, err
+		}
+
+		var prompt prompts.Prompt
+		if err := json.Unmarshal(raw, &prompt); err != nil {
+			return nil, fmt.Errorf("failed to parse prompt: %w", err)
+		}
+
+		if prompt.String == "" {
+			return nil, ErrNoTemplate
+		}
+
+		return []mcp.ResourceContents{mcp.TextResourceContents{
+			Meta:     nil,
+			URI:      request.Params.URI,
+			MIMEType: mimeTypeText,
+			Text:     prompt.String,
+		}}, nil
+	})
+}
+
+// collectionHandler reads a collection, along with the prompts in it, as JSON.
+func (c *Catalog) collectionHandler() server.ResourceTemplateHandlerFunc {
+	return c.withContext(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		collectionID, err := uriVariable(request, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		collection, err := getCollection(ctx, c.portkey, collectionID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(collection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal collection: %w", err)
+		}
+
+		return jsonContents(request.Params.URI, data), nil
+	})
+}
+
+// configHandler reads a config as JSON.
+func (c *Catalog) configHandler() server.ResourceTemplateHandlerFunc {
+	return c.withContext(func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		slug, err := uriVariable(request, "slug")
+		if err != nil {
+			return nil, err
+		}
+
+		cfg, err := getJSON(ctx, c.portkey, fmt.Sprintf("%s/configs/%s", c.portkey.BaseURL, url.PathEscape(slug)))
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonContents(request.Params.URI, cfg), nil
+	})
+}
+
+func jsonContents(uri string, data []byte) []mcp.ResourceContents {
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		Meta:     nil,
+		URI:      uri,
+		MIMEType: mimeTypeJSON,
+		Text:     string(data),
+	}}
+}
+
+// uriVariable returns a variable matched from the resource's URI template.
+func uriVariable(request mcp.ReadResourceRequest, name string) (string, error) {
+	var value string
+
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) > 0 {
+			value = v[0]
+		}
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("%w: %s", ErrMissingURIVariable, name)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+// CollectionResponse is the content of a collection resource: the collection itself, and the prompts in it.
+type CollectionResponse struct {
+	Collection json.RawMessage          `json:"collection"`
+	Prompts    []promptslist.PromptData `json:"prompts"`
+}
+
+// getJSON calls a Portkey API that returns a single object, and returns the object as-is.
+func getJSON(ctx context.Context, portkey config.Portkey, apiURL string) (json.RawMessage, error) {
+	var raw json.RawMessage
+
+	errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+		Method: http.MethodGet,
+		URL:    apiURL,
+		Body:   nil,
+	}, &raw)
+	if errResult != nil {
+		return nil, tools.ResultError(errResult)
+	}
+
+	return raw, nil
+}
+
+// promptURL returns the URL of the Portkey Retrieve Prompt API, or of the Retrieve Prompt Version API when a version
+// is provided.
+func promptURL(portkey config.Portkey, slug, version string) string {
+	if version == "" {
+		return fmt.Sprintf("%s/prompts/%s", portkey.BaseURL, url.PathEscape(slug))
+	}
+
+	return fmt.Sprintf("%s/prompts/%s/versions/%s", portkey.BaseURL, url.PathEscape(slug), url.PathEscape(version))
+}
+
+func getCollection(ctx context.Context, portkey config.Portkey, collectionID string) (CollectionResponse, error) {
+	collection, err := getJSON(ctx, portkey,
+		fmt.Sprintf("%s/collections/%s", portkey.BaseURL, url.PathEscape(collectionID)))
+	if err != nil {
+		return CollectionResponse{}, err
+	}
+
+	collectionPrompts, err := prompts.List(ctx, portkey, []string{collectionID}, "")
+	if err != nil {
+		return CollectionResponse{}, fmt.Errorf("failed to list prompts in collection: %w", err)
+	}
+
+	return CollectionResponse{Collection: collection, Prompts: collectionPrompts}, nil
+}
@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Subscriptions tracks which sessions subscribed to which resource URIs.
+type Subscriptions struct {
+	mu sync.RWMutex
+
+	// bySession holds the subscribed URIs, keyed by session ID.
+	bySession map[string]map[string]bool
+}
+
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		mu:        sync.RWMutex{},
+		bySession: make(map[string]map[string]bool),
+	}
+}
+
+// AddHooks keeps the subscriptions in sync with resources/subscribe and resources/unsubscribe requests, and drops the
+// subscriptions of sessions that go away.
+func (s *Subscriptions) AddHooks(hooks *server.Hooks) {
+	hooks.AddAfterSubscribe(func(ctx context.Context, _ any, message *mcp.SubscribeRequest, _ *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			s.Subscribe(session.SessionID(), message.Params.URI)
+		}
+	})
+
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, _ any, message *mcp.UnsubscribeRequest, _ *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			s.Unsubscribe(session.SessionID(), message.Params.URI)
+		}
+	})
+
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.RemoveSession(session.SessionID())
+	})
+}
+
+func (s *Subscriptions) Subscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bySession[sessionID] == nil {
+		s.bySession[sessionID] = make(map[string]bool)
+	}
+
+	s.bySession[sessionID][uri] = true
+
+	slog.Debug("session subscribed to resource", "session_id", sessionID, "resource_uri", uri)
+}
+
+func (s *Subscriptions) Unsubscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bySession[sessionID], uri)
+
+	if len(s.bySession[sessionID]) == 0 {
+		delete(s.bySession, sessionID)
+	}
+
+	slog.Debug("session unsubscribed from resource", "session_id", sessionID, "resource_uri", uri)
+}
+
+func (s *Subscriptions) RemoveSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bySession, sessionID)
+}
+
+// Subscribers returns the IDs of the sessions subscribed to the URI, sorted.
+func (s *Subscriptions) Subscribers(uri string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sessionIDs []string
+
+	for sessionID, uris := range s.bySession {
+		if uris[uri] {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+
+	slices.Sort(sessionIDs)
+
+	return sessionIDs
+}
@@ -0,0 +1,33 @@
+package resources
+
+import "fmt"
+
+// URI templates of the Portkey resources.
+const (
+	PromptURITemplate                = "portkey://prompts/{slug}"
+	PromptVersionURITemplate         = "portkey://prompts/{slug}@{version}"
+	PromptTemplateURITemplate        = "portkey://prompts/{slug}/template"
+	PromptVersionTemplateURITemplate = "portkey://prompts/{slug}@{version}/template"
+	CollectionURITemplate            = "portkey://collections/{id}"
+	ConfigURITemplate                = "portkey://configs/{slug}"
+)
+
+const (
+	mimeTypeJSON = "application/json"
+	mimeTypeText = "text/plain"
+)
+
+// PromptURI returns the URI of the published version of a prompt.
+func PromptURI(slug string) string {
+	return fmt.Sprintf("portkey://prompts/%s", slug)
+}
+
+// PromptTemplateURI returns the URI of the raw template of the published version of a prompt.
+func PromptTemplateURI(slug string) string {
+	return fmt.Sprintf("portkey://prompts/%s/template", slug)
+}
+
+// CollectionURI returns the URI of a collection.
+func CollectionURI(collectionID string) string {
+	return fmt.Sprintf("portkey://collections/%s", collectionID)
+}
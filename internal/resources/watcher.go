@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+)
+
+// Watcher polls Portkey for prompt changes, and sends a notifications/resources/updated notification to every
+// session subscribed to a resource that changed.
+type Watcher struct {
+	portkey       config.Portkey
+	cfg           config.ResourceSubscriptions
+	mcpServer     *server.MCPServer
+	httpClient    *http.Client
+	subscriptions *Subscriptions
+
+	// previous is the prompt listing of the last successful poll, or nil before the first one.
+	previous []promptslist.PromptData
+}
+
+func NewWatcher(
+	portkey config.Portkey,
+	cfg config.ResourceSubscriptions,
+	mcpServer *server.MCPServer,
+	httpClient *http.Client,
+	subscriptions *Subscriptions,
+) *Watcher {
+	return &Watcher{
+		portkey:       portkey,
+		cfg:           cfg,
+		mcpServer:     mcpServer,
+		httpClient:    httpClient,
+		subscriptions: subscriptions,
+		previous:      nil,
+	}
+}
+
+// Run polls for prompt changes every poll interval, plus jitter, until the context is cancelled. Failed polls are
+// logged and retried at the next interval.
+func (w *Watcher) Run(ctx context.Context) {
+	if err := w.Poll(ctx); err != nil {
+		slog.Warn("failed to poll portkey prompts for changes", "error", err)
+	}
+
+	for {
+		timer := time.NewTimer(w.nextPollDelay())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+			if err := w.Poll(ctx); err != nil {
+				slog.Warn("failed to poll portkey prompts for changes", "error", err)
+			}
+		}
+	}
+}
+
+// Poll lists the prompts from Portkey, and notifies the sessions subscribed to the resources that changed since the
+// previous poll. The first poll only records the current state.
+func (w *Watcher) Poll(ctx context.Context) error {
+	ctx = middleware.ContextWithHTTPClient(ctx, w.httpClient)
+
+	current, err := prompts.List(ctx, w.portkey, w.cfg.CollectionIDs, w.cfg.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	previous := w.previous
+	w.previous = current
+
+	if previous == nil {
+		return nil
+	}
+
+	changed := ChangedURIs(previous, current)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	slog.Debug("detected changes to portkey resources", "resource_uris", changed)
+
+	for _, uri := range changed {
+		w.notify(uri)
+	}
+
+	return nil
+}
+
+func (w *Watcher) notify(uri string) {
+	for _, sessionID := range w.subscriptions.Subscribers(uri) {
+		err := w.mcpServer.SendNotificationToSpecificClient(
+			sessionID,
+			mcp.MethodNotificationResourceUpdated,
+			map[string]any{"uri": uri},
+		)
+		if err != nil {
+			slog.Warn("failed to notify session of resource update",
+				"session_id", sessionID,
+				"resource_uri", uri,
+				"error", err,
+			)
+
+			continue
+		}
+
+		slog.Debug("notified session of resource update", "session_id", sessionID, "resource_uri", uri)
+	}
+}
+
+func (w *Watcher) nextPollDelay() time.Duration {
+	if w.cfg.PollJitter <= 0 {
+		return w.cfg.PollInterval
+	}
+
+	return w.cfg.PollInterval + rand.N(w.cfg.PollJitter) //nolint:gosec // Jitter doesn't need a secure source.
+}
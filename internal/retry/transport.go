@@ -0,0 +1,179 @@
+// Package retry retries requests to Portkey after transient failures, with exponential backoff and jitter, and
+// honors Retry-After.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+type allowKey struct{}
+
+// Allow returns a copy of the context whose requests are retried whatever their method. It is for POSTs that change
+// nothing, like rendering a prompt.
+func Allow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowKey{}, true)
+}
+
+func allowed(req *http.Request) bool {
+	if allow, _ := req.Context().Value(allowKey{}).(bool); allow {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Transport returns an http.RoundTripper that retries requests made through next, which is http.DefaultTransport if
+// nil, as configured. A retry is only made if it can start before the request's context is done.
+func Transport(cfg config.Retry, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{cfg: cfg, next: next}
+}
+
+type transport struct {
+	cfg  config.Retry
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.cfg.MaxAttempts <= 1 || !allowed(req) || (req.Body != nil && req.GetBody == nil) {
+		return t.next.RoundTrip(req) //nolint:wrapcheck
+	}
+
+	ctx := req.Context()
+	lgr := middleware.GetLogger(ctx)
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt == t.cfg.MaxAttempts || !t.retryable(resp, err) {
+			return resp, err //nolint:wrapcheck
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			lgr.Info("not retrying portkey request, as the retry would start after the deadline",
+				"attempt", attempt, "wait", wait, "status_code", statusCode(resp), "error", err)
+
+			return resp, err //nolint:wrapcheck
+		}
+
+		lgr.Info("retrying portkey request",
+			"attempt", attempt, "max_attempts", t.cfg.MaxAttempts, "wait", wait,
+			"status_code", statusCode(resp), "error", err)
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, fmt.Errorf("gave up retrying after attempt %d: %w", attempt, ctx.Err())
+		}
+	}
+}
+
+// rewind returns the request to send for an attempt, with a fresh copy of the body for every attempt after the first.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+
+	return attemptReq, nil
+}
+
+// retryable reports whether the failure is transient: a connection reset, or a response with a retryable status code.
+func (t *transport) retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+	}
+
+	return slices.Contains(t.cfg.StatusCodes, resp.StatusCode)
+}
+
+// backoff returns the wait before the retry after the given attempt: the exponential backoff less some jitter, or the
+// response's Retry-After if that's longer.
+func (t *transport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := t.cfg.MaxBackoff
+	if shift := attempt - 1; shift < 32 && t.cfg.BaseBackoff<<shift < t.cfg.MaxBackoff { //nolint:mnd
+		wait = t.cfg.BaseBackoff << shift
+	}
+
+	wait -= time.Duration(rand.Float64() * t.cfg.Jitter * float64(wait)) //nolint:gosec
+
+	if retryAfter := retryAfter(resp); retryAfter > wait {
+		return retryAfter
+	}
+
+	return wait
+}
+
+// retryAfter parses the Retry-After header of the response, which is either a number of seconds or an HTTP date. It
+// returns 0 if there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
+
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+
+	return resp.StatusCode
+}
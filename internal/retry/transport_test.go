@@ -0,0 +1,150 @@
+package retry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/retry"
+)
+
+func newPolicy() config.Retry {
+	return config.Retry{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+		Jitter:      0.2,
+		StatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+}
+
+// newPortkey returns a server that fails every request with failStatus until the given attempt, and records the
+// bodies it received.
+func newPortkey(t *testing.T, failStatus, succeedOnAttempt int, header http.Header) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var (
+		attempts atomic.Int32
+		bodies   []string
+	)
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if int(attempts.Add(1)) < succeedOnAttempt {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+
+			w.WriteHeader(failStatus)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(portkey.Close)
+
+	return portkey, &bodies
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		allow        bool
+		failStatus   int
+		succeedOn    int
+		header       http.Header
+		timeout      time.Duration
+		wantStatus   int
+		wantAttempts int
+	}{
+		{
+			name: "retries a get until it succeeds", method: http.MethodGet, failStatus: http.StatusServiceUnavailable,
+			succeedOn: 3, wantStatus: http.StatusOK, wantAttempts: 3,
+		},
+		{
+			name: "gives up after max attempts", method: http.MethodGet, failStatus: http.StatusServiceUnavailable,
+			succeedOn: 5, wantStatus: http.StatusServiceUnavailable, wantAttempts: 3,
+		},
+		{
+			name: "doesn't retry other statuses", method: http.MethodGet, failStatus: http.StatusInternalServerError,
+			succeedOn: 2, wantStatus: http.StatusInternalServerError, wantAttempts: 1,
+		},
+		{
+			name: "doesn't retry a post", method: http.MethodPost, failStatus: http.StatusServiceUnavailable,
+			succeedOn: 2, wantStatus: http.StatusServiceUnavailable, wantAttempts: 1,
+		},
+		{
+			name: "retries an allowed post", method: http.MethodPost, allow: true,
+			failStatus: http.StatusServiceUnavailable, succeedOn: 2, wantStatus: http.StatusOK, wantAttempts: 2,
+		},
+		{
+			name: "honors retry-after", method: http.MethodGet, failStatus: http.StatusTooManyRequests,
+			succeedOn: 2, header: http.Header{"Retry-After": {"1"}}, wantStatus: http.StatusOK, wantAttempts: 2,
+		},
+		{
+			name: "doesn't retry past the deadline", method: http.MethodGet, failStatus: http.StatusTooManyRequests,
+			succeedOn: 2, header: http.Header{"Retry-After": {"60"}}, timeout: time.Second,
+			wantStatus: http.StatusTooManyRequests, wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			portkey, bodies := newPortkey(t, tt.failStatus, tt.succeedOn, tt.header)
+
+			ctx := context.Background()
+			if tt.allow {
+				ctx = retry.Allow(ctx)
+			}
+
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, portkey.URL, strings.NewReader(`{"a":1}`))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			client := &http.Client{Transport: retry.Transport(newPolicy(), nil)} //nolint:exhaustruct
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("failed to call portkey: %v", err)
+			}
+
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			if len(*bodies) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", len(*bodies), tt.wantAttempts)
+			}
+
+			for i, body := range *bodies {
+				if body != `{"a":1}` {
+					t.Errorf("attempt %d body = %q, want the request body", i+1, body)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package setup
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/kelseyhightower/envconfig"
 
@@ -16,6 +17,29 @@ func AppConfig(buildTimeVars config.BuildTimeVars) (config.App, error) {
 		return config.App{}, fmt.Errorf("error loading environment variables into app config: %w", err)
 	}
 
+	cfg.Portkey.Profile = config.DefaultProfile
+	cfg.PortkeyProfiles.Portkey = make(map[string]config.Portkey, len(cfg.PortkeyProfiles.Names))
+
+	for _, name := range cfg.PortkeyProfiles.Names {
+		var portkey config.Portkey
+
+		envPrefix := cfg.PortkeyProfiles.EnvPrefix(name)
+
+		err := envconfig.Process(envPrefix, &portkey)
+		if err != nil {
+			return config.App{}, fmt.Errorf("error loading environment variables into portkey profile %q: %w", name, err)
+		}
+
+		// A profile that doesn't set its own mode takes the default profile's, so that it can't be called with the
+		// server's key unless the operator chose that.
+		if _, ok := os.LookupEnv(envPrefix + "_API_KEY_MODE"); !ok {
+			portkey.APIKeyMode = cfg.Portkey.APIKeyMode
+		}
+
+		portkey.Profile = name
+		cfg.PortkeyProfiles.Portkey[name] = portkey
+	}
+
 	cfg.BuildTimeVars = buildTimeVars
 
 	err = cfg.Validate()
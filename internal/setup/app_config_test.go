@@ -0,0 +1,162 @@
+package setup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/setup"
+)
+
+// TestAppConfigRequiresServerKeyForCatalogs makes sure that prompts and resources, which are refreshed outside of any
+// client session, can't be enabled without the server's key in client-required mode.
+func TestAppConfigRequiresServerKeyForCatalogs(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr error
+	}{
+		{
+			name:    "no catalogs",
+			env:     map[string]string{},
+			wantErr: nil,
+		},
+		{
+			name:    "prompts",
+			env:     map[string]string{"PROMPTS_ENABLED": "true"},
+			wantErr: config.ErrServerAPIKeyRequired,
+		},
+		{
+			name:    "resources",
+			env:     map[string]string{"RESOURCES_ENABLED": "true"},
+			wantErr: config.ErrServerAPIKeyRequired,
+		},
+		{
+			name:    "prompts with a server key",
+			env:     map[string]string{"PROMPTS_ENABLED": "true", "PORTKEY_API_KEY": "test-key"},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PORTKEY_API_KEY", "")
+			t.Setenv("PORTKEY_API_KEY_MODE", string(config.APIKeyModeClientRequired))
+
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AppConfig() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestAppConfigDisablesWriteTools makes sure that tools which change Portkey resources are off unless enabled, while
+// read-only tools are on.
+func TestAppConfigDisablesWriteTools(t *testing.T) {
+	t.Setenv("PORTKEY_API_KEY", "test-key")
+	t.Setenv("TOOLS_GUARDRAIL_UPDATE_ENABLED", "true")
+
+	cfg, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("AppConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    bool
+	}{
+		{name: "guardrail_create", enabled: cfg.Tools.GuardrailCreate.Enabled, want: false},
+		{name: "guardrail_delete", enabled: cfg.Tools.GuardrailDelete.Enabled, want: false},
+		{name: "guardrail_update", enabled: cfg.Tools.GuardrailUpdate.Enabled, want: true},
+		{name: "guardrail_get", enabled: cfg.Tools.GuardrailGet.Enabled, want: true},
+		{name: "guardrails_list", enabled: cfg.Tools.GuardrailsList.Enabled, want: true},
+		{name: "integration_create", enabled: cfg.Tools.IntegrationCreate.Enabled, want: false},
+		{name: "integration_models_update", enabled: cfg.Tools.IntegrationModelsUpdate.Enabled, want: false},
+		{name: "integration_update", enabled: cfg.Tools.IntegrationUpdate.Enabled, want: false},
+		{name: "integration_workspaces_update", enabled: cfg.Tools.IntegrationWorkspacesUpdate.Enabled, want: false},
+		{name: "integrations_list", enabled: cfg.Tools.IntegrationsList.Enabled, want: true},
+		{name: "rate_limit_create", enabled: cfg.Tools.RateLimitCreate.Enabled, want: false},
+		{name: "rate_limit_delete", enabled: cfg.Tools.RateLimitDelete.Enabled, want: false},
+		{name: "rate_limit_update", enabled: cfg.Tools.RateLimitUpdate.Enabled, want: false},
+		{name: "rate_limits_list", enabled: cfg.Tools.RateLimitsList.Enabled, want: true},
+		{name: "usage_limit_create", enabled: cfg.Tools.UsageLimitCreate.Enabled, want: false},
+		{name: "usage_limit_delete", enabled: cfg.Tools.UsageLimitDelete.Enabled, want: false},
+		{name: "usage_limit_update", enabled: cfg.Tools.UsageLimitUpdate.Enabled, want: false},
+		{name: "usage_limits_list", enabled: cfg.Tools.UsageLimitsList.Enabled, want: true},
+	}
+
+	for _, tt := range tests {
+		if tt.enabled != tt.want {
+			t.Errorf("%s enabled = %v, want %v", tt.name, tt.enabled, tt.want)
+		}
+	}
+}
+
+// TestAppConfigProfileAPIKeyModes makes sure that named profiles take the default profile's API key mode unless they
+// set their own, so that clients can't call them with the server's key unless the operator chose that.
+func TestAppConfigProfileAPIKeyModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantMode config.APIKeyMode
+		wantErr  error
+	}{
+		{
+			name:     "inherits server mode",
+			env:      map[string]string{},
+			wantMode: config.APIKeyModeServer,
+			wantErr:  nil,
+		},
+		{
+			name: "inherits client mode, with its own header",
+			env: map[string]string{
+				"PORTKEY_API_KEY_MODE":                   string(config.APIKeyModeClientRequired),
+				"PORTKEY_PROFILE_STAGING_API_KEY_HEADER": "X-Portkey-Staging-Api-Key",
+			},
+			wantMode: config.APIKeyModeClientRequired,
+			wantErr:  nil,
+		},
+		{
+			name:     "inherits client mode, sharing the default header",
+			env:      map[string]string{"PORTKEY_API_KEY_MODE": string(config.APIKeyModeClientRequired)},
+			wantMode: "",
+			wantErr:  config.ErrDuplicateAPIKeyHeader,
+		},
+		{
+			name: "sets server mode",
+			env: map[string]string{
+				"PORTKEY_API_KEY_MODE":                 string(config.APIKeyModeClientRequired),
+				"PORTKEY_PROFILE_STAGING_API_KEY_MODE": string(config.APIKeyModeServer),
+			},
+			wantMode: config.APIKeyModeServer,
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PORTKEY_API_KEY", "test-key")
+			t.Setenv("PORTKEY_PROFILES", "staging")
+			t.Setenv("PORTKEY_PROFILE_STAGING_API_KEY", "staging-key")
+
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AppConfig() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if mode := cfg.PortkeyProfiles.Portkey["staging"].APIKeyMode; mode != tt.wantMode {
+				t.Errorf("staging api key mode = %q, want %q", mode, tt.wantMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package setup
+
+import (
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+)
+
+// HealthChecker returns the readiness checker of the admin endpoints, which calls Portkey with the default profile,
+// and watches the circuit breakers of its host.
+func HealthChecker(cfg config.App, upstream Upstream) (*health.Checker, error) {
+	httpClient, err := portkeyHTTPClient(cfg.Portkey, upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	return health.NewChecker(cfg.Admin, cfg.Portkey, httpClient, upstream.Breakers), nil
+}
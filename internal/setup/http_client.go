@@ -0,0 +1,30 @@
+package setup
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/retry"
+)
+
+// portkeyHTTPClient returns the HTTP client for requests to Portkey with the given config, whose requests are
+// recorded by the upstream's telemetry, and go through its circuit breakers. Each retry of a request is recorded, and
+// goes through the breakers, as a request of its own.
+func portkeyHTTPClient(portkey config.Portkey, upstream Upstream) (*http.Client, error) {
+	httpClient, err := portkey.Client.FromConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client from config: %w", err)
+	}
+
+	httpClient.Transport = upstream.Telemetry.Metrics.Transport(httpClient.Transport)
+
+	if upstream.Telemetry.Tracer != nil {
+		httpClient.Transport = upstream.Telemetry.Tracer.Transport(httpClient.Transport)
+	}
+
+	httpClient.Transport = upstream.Breakers.Transport(portkey.Client.Breaker, httpClient.Transport)
+	httpClient.Transport = retry.Transport(portkey.Client.Retry, httpClient.Transport)
+
+	return httpClient, nil
+}
@@ -0,0 +1,38 @@
+package setup
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/prompts"
+)
+
+// MCPPrompts exposes Portkey prompts as MCP prompts, and keeps them up to date in the background until the context
+// is cancelled.
+func MCPPrompts(ctx context.Context, cfg config.App, mcpServer *server.MCPServer, upstream Upstream) error {
+	if !cfg.Prompts.Enabled {
+		slog.Info("portkey prompts are not exposed as mcp prompts")
+
+		return nil
+	}
+
+	httpClient, err := portkeyHTTPClient(cfg.Portkey, upstream)
+	if err != nil {
+		return err
+	}
+
+	registry := prompts.NewRegistry(cfg.Portkey, cfg.Prompts, mcpServer, httpClient)
+
+	slog.Info("exposing portkey prompts as mcp prompts",
+		"collection_ids", cfg.Prompts.CollectionIDs,
+		"workspace_id", cfg.Prompts.WorkspaceID,
+		"refresh_interval", cfg.Prompts.RefreshInterval,
+	)
+
+	go registry.Run(ctx)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package setup
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/resources"
+)
+
+// MCPResources exposes Portkey prompts, collections and configs as MCP resources, and keeps the listed prompts up to
+// date in the background until the context is cancelled. When subscriptions are enabled, the hooks track subscribed
+// sessions, and they are notified of prompt changes.
+func MCPResources(
+	ctx context.Context,
+	cfg config.App,
+	mcpServer *server.MCPServer,
+	hooks *server.Hooks,
+	upstream Upstream,
+) error {
+	if !cfg.Resources.Enabled {
+		slog.Info("portkey resources are not exposed as mcp resources")
+
+		return nil
+	}
+
+	httpClient, err := portkeyHTTPClient(cfg.Portkey, upstream)
+	if err != nil {
+		return err
+	}
+
+	catalog := resources.NewCatalog(cfg.Portkey, cfg.Resources, mcpServer, httpClient)
+	catalog.RegisterTemplates()
+
+	slog.Info("exposing portkey resources as mcp resources", "refresh_interval", cfg.Resources.RefreshInterval)
+
+	go catalog.Run(ctx)
+
+	if !cfg.Resources.Subscriptions.Enabled {
+		return nil
+	}
+
+	subscriptions := resources.NewSubscriptions()
+	subscriptions.AddHooks(hooks)
+
+	watcher := resources.NewWatcher(cfg.Portkey, cfg.Resources.Subscriptions, mcpServer, httpClient, subscriptions)
+
+	slog.Info("notifying resource subscribers of portkey prompt changes",
+		"collection_ids", cfg.Resources.Subscriptions.CollectionIDs,
+		"workspace_id", cfg.Resources.Subscriptions.WorkspaceID,
+		"poll_interval", cfg.Resources.Subscriptions.PollInterval,
+		"poll_jitter", cfg.Resources.Subscriptions.PollJitter,
+	)
+
+	go watcher.Run(ctx)
+
+	return nil
+}
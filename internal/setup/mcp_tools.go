@@ -8,27 +8,36 @@ import (
 
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/analytics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/feedback"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/guardrails"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/integrations"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logexport"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logssearch"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/policies"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptcreate"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptrender"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptslist"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/providerslist"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/traceget"
 )
 
-func MCPTools(cfg config.App, mcpServer *server.MCPServer, downstreamTools ...tools.Tuple) error {
-	httpClient, err := cfg.Portkey.Client.FromConfig()
+// MCPTools registers every enabled tool. Tool calls, and the requests to Portkey that they make, are recorded by
+// the upstream's telemetry.
+func MCPTools(cfg config.App, mcpServer *server.MCPServer, upstream Upstream, downstreamTools ...tools.Tuple) error {
+	allTools, err := profileTools(cfg, upstream)
 	if err != nil {
-		return fmt.Errorf("failed to create http client from config: %w", err)
+		return err
 	}
 
-	middlewares := []middleware.Middleware{
-		middleware.WithToolCallLogging,
-		middleware.WithHTTPClient(httpClient),
+	httpClient, err := portkeyHTTPClient(cfg.Portkey, upstream)
+	if err != nil {
+		return err
 	}
 
-	allTools := []tools.Tuple{
-		promptcreate.NewTool(cfg.Portkey, cfg.Tools.PromptCreate),
-		promptrender.NewTool(cfg.Portkey, cfg.Tools.PromptRender),
-		promptslist.NewTool(cfg.Portkey, cfg.Tools.PromptsList),
+	for i := range downstreamTools {
+		downstreamTools[i].Handler = addMiddleware(downstreamTools[i].Handler, middleware.WithHTTPClient(httpClient))
 	}
 
 	allTools = append(allTools, downstreamTools...)
@@ -40,13 +49,97 @@ func MCPTools(cfg config.App, mcpServer *server.MCPServer, downstreamTools ...to
 
 		mcpServer.AddTool(
 			*t.Tool,
-			addMiddleware(t.Handler, middlewares...),
+			addMiddleware(t.Handler, upstream.Telemetry.toolMiddlewares()...),
 		)
 	}
 
 	return nil
 }
 
+// profileTools creates the Portkey tools for every profile. Without named profiles, the default profile's tools are
+// returned as-is. Otherwise, each tool takes a profile argument, and calls the handler of the chosen profile.
+func profileTools(cfg config.App, upstream Upstream) ([]tools.Tuple, error) {
+	profiles := cfg.PortkeyProfiles.All()
+	toolsByProfile := make(map[string][]tools.Tuple, len(profiles))
+
+	for _, name := range profiles {
+		portkey, _ := cfg.PortkeyProfile(name)
+
+		httpClient, err := portkeyHTTPClient(portkey, upstream)
+		if err != nil {
+			return nil, fmt.Errorf("portkey profile %q: %w", name, err)
+		}
+
+		profileTools := portkeyTools(cfg, portkey)
+		for i := range profileTools {
+			profileTools[i].Handler = addMiddleware(profileTools[i].Handler, middleware.WithHTTPClient(httpClient))
+		}
+
+		toolsByProfile[name] = profileTools
+	}
+
+	defaultTools := toolsByProfile[config.DefaultProfile]
+	if !cfg.PortkeyProfiles.Enabled() {
+		return defaultTools, nil
+	}
+
+	combinedTools := make([]tools.Tuple, 0, len(defaultTools))
+
+	for i, tuple := range defaultTools {
+		handlers := make(map[string]server.ToolHandlerFunc, len(profiles))
+		for _, name := range profiles {
+			handlers[name] = toolsByProfile[name][i].Handler
+		}
+
+		combinedTools = append(combinedTools,
+			tools.WithProfiles(tuple, handlers, profiles, cfg.PortkeyProfiles.Default))
+	}
+
+	return combinedTools, nil
+}
+
+// portkeyTools creates every Portkey tool, calling Portkey with the given profile's config.
+func portkeyTools(cfg config.App, portkey config.Portkey) []tools.Tuple {
+	return []tools.Tuple{
+		analytics.NewGraphTool(portkey, cfg.Tools.AnalyticsGraph),
+		analytics.NewGroupTool(portkey, cfg.Tools.AnalyticsGroup),
+		feedback.NewCreateTool(portkey, cfg.Tools.FeedbackCreate),
+		feedback.NewUpdateTool(portkey, cfg.Tools.FeedbackUpdate),
+		guardrails.NewCreateTool(portkey, cfg.Tools.GuardrailCreate),
+		guardrails.NewDeleteTool(portkey, cfg.Tools.GuardrailDelete),
+		guardrails.NewGetTool(portkey, cfg.Tools.GuardrailGet),
+		guardrails.NewUpdateTool(portkey, cfg.Tools.GuardrailUpdate),
+		guardrails.NewListTool(portkey, cfg.Tools.GuardrailsList),
+		integrations.NewCreateTool(portkey, cfg.Tools.IntegrationCreate),
+		integrations.NewGetTool(portkey, cfg.Tools.IntegrationGet),
+		integrations.NewModelsListTool(portkey, cfg.Tools.IntegrationModelsList),
+		integrations.NewModelsUpdateTool(portkey, cfg.Tools.IntegrationModelsUpdate),
+		integrations.NewUpdateTool(portkey, cfg.Tools.IntegrationUpdate),
+		integrations.NewWorkspacesListTool(portkey, cfg.Tools.IntegrationWorkspacesList),
+		integrations.NewWorkspacesUpdateTool(portkey, cfg.Tools.IntegrationWorkspacesUpdate),
+		integrations.NewListTool(portkey, cfg.Tools.IntegrationsList),
+		logexport.NewCreateTool(portkey, cfg.Tools.LogExportCreate),
+		logexport.NewDownloadTool(portkey, cfg.Tools.LogExportDownload),
+		logexport.NewGetTool(portkey, cfg.Tools.LogExportGet),
+		logexport.NewStartTool(portkey, cfg.Tools.LogExportStart),
+		logssearch.NewTool(portkey, cfg.Tools.LogsSearch),
+		promptcreate.NewTool(portkey, cfg.Results, cfg.Tools.PromptCreate),
+		promptrender.NewTool(portkey, cfg.Results, cfg.Tools.PromptRender),
+		promptslist.NewTool(portkey, cfg.Results, cfg.Tools.PromptsList),
+		providerslist.NewTool(portkey, cfg.Tools.ProvidersList),
+		policies.NewRateLimitCreateTool(portkey, cfg.Tools.RateLimitCreate),
+		policies.NewRateLimitDeleteTool(portkey, cfg.Tools.RateLimitDelete),
+		policies.NewRateLimitUpdateTool(portkey, cfg.Tools.RateLimitUpdate),
+		policies.NewRateLimitsListTool(portkey, cfg.Tools.RateLimitsList),
+		traceget.NewTool(portkey, cfg.Tools.TraceGet),
+		policies.NewUsageLimitCreateTool(portkey, cfg.Tools.UsageLimitCreate),
+		policies.NewUsageLimitDeleteTool(portkey, cfg.Tools.UsageLimitDelete),
+		policies.NewUsageLimitUpdateTool(portkey, cfg.Tools.UsageLimitUpdate),
+		policies.NewUsageLimitsConsumptionTool(portkey, cfg.Tools.UsageLimitsConsumption),
+		policies.NewUsageLimitsListTool(portkey, cfg.Tools.UsageLimitsList),
+	}
+}
+
 // getEnabledTools filters out disabled tools from the provided list.
 func getEnabledTools(allTools []tools.Tuple) []tools.Tuple {
 	enabledTools := make([]tools.Tuple, 0, len(allTools))
@@ -0,0 +1,190 @@
+package setup_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/setup"
+)
+
+func newUpstream() setup.Upstream {
+	return setup.Upstream{
+		Telemetry: setup.Telemetry{Metrics: metrics.New(), Tracer: nil},
+		Breakers:  breaker.New(),
+	}
+}
+
+// TestMCPToolsDeclareAnnotationsAndOutputSchemas makes sure that clients can tell what every tool does to the Portkey
+// account, and what it returns.
+func TestMCPToolsDeclareAnnotationsAndOutputSchemas(t *testing.T) {
+	t.Setenv("PORTKEY_API_KEY", "test-key")
+	t.Setenv("TOOLS_PROMPT_CREATE_ENABLED", "true")
+
+	cfg, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("AppConfig() error = %v", err)
+	}
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0")
+
+	if err := setup.MCPTools(cfg, mcpServer, newUpstream()); err != nil {
+		t.Fatalf("MCPTools() error = %v", err)
+	}
+
+	for name, tool := range mcpServer.ListTools() {
+		annotations := tool.Tool.Annotations
+
+		if annotations.Title == "" {
+			t.Errorf("%s: missing title annotation", name)
+		}
+
+		if annotations.ReadOnlyHint == nil || annotations.DestructiveHint == nil ||
+			annotations.IdempotentHint == nil || annotations.OpenWorldHint == nil {
+			t.Errorf("%s: missing hint annotations", name)
+		}
+
+		if len(tool.Tool.RawOutputSchema) == 0 && tool.Tool.OutputSchema.Type != "object" {
+			t.Errorf("%s: missing output schema", name)
+		}
+	}
+}
+
+// TestMCPToolsDestructiveHints makes sure that only tools which can lose existing settings or data are marked
+// destructive, so that clients can tell them apart from updates that only change what they are given.
+func TestMCPToolsDestructiveHints(t *testing.T) {
+	t.Setenv("PORTKEY_API_KEY", "test-key")
+
+	tests := []struct {
+		name            string
+		wantDestructive bool
+	}{
+		{name: "feedback_update", wantDestructive: false},
+		{name: "guardrail_delete", wantDestructive: true},
+		{name: "guardrail_update", wantDestructive: true},
+		{name: "integration_models_update", wantDestructive: false},
+		{name: "integration_update", wantDestructive: false},
+		{name: "integration_workspaces_update", wantDestructive: true},
+		{name: "rate_limit_delete", wantDestructive: true},
+		{name: "rate_limit_update", wantDestructive: false},
+		{name: "usage_limit_delete", wantDestructive: true},
+		{name: "usage_limit_update", wantDestructive: true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("TOOLS_"+strings.ToUpper(tt.name)+"_ENABLED", "true")
+	}
+
+	cfg, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("AppConfig() error = %v", err)
+	}
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0")
+
+	if err := setup.MCPTools(cfg, mcpServer, newUpstream()); err != nil {
+		t.Fatalf("MCPTools() error = %v", err)
+	}
+
+	listed := mcpServer.ListTools()
+
+	for _, tt := range tests {
+		tool, ok := listed[tt.name]
+		if !ok {
+			t.Errorf("%s: not registered", tt.name)
+
+			continue
+		}
+
+		if hint := tool.Tool.Annotations.DestructiveHint; hint == nil || *hint != tt.wantDestructive {
+			t.Errorf("%s: destructive hint = %v, want %v", tt.name, hint != nil && *hint, tt.wantDestructive)
+		}
+	}
+}
+
+// TestMCPToolsCallTheChosenProfile makes sure that tool calls go to the Portkey instance of the profile they name, with
+// that profile's API key, and that the profiles are advertised to the agent.
+func TestMCPToolsCallTheChosenProfile(t *testing.T) {
+	newPortkey := func(name string) *httptest.Server {
+		portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Portkey-Api-Key") != name+"-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"object":"list","total":0,"data":[]}`))
+		}))
+		t.Cleanup(portkey.Close)
+
+		return portkey
+	}
+
+	t.Setenv("PORTKEY_API_KEY", "default-key")
+	t.Setenv("PORTKEY_BASE_URL", newPortkey("default").URL)
+	t.Setenv("PORTKEY_PROFILES", "staging")
+	t.Setenv("PORTKEY_DEFAULT_PROFILE", "staging")
+	t.Setenv("PORTKEY_PROFILE_STAGING_API_KEY", "staging-key")
+	t.Setenv("PORTKEY_PROFILE_STAGING_BASE_URL", newPortkey("staging").URL)
+
+	cfg, err := setup.AppConfig(config.BuildTimeVars{AppVersion: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("AppConfig() error = %v", err)
+	}
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0")
+
+	if err := setup.MCPTools(cfg, mcpServer, newUpstream()); err != nil {
+		t.Fatalf("MCPTools() error = %v", err)
+	}
+
+	tool := mcpServer.GetTool("providers_list")
+	if tool == nil {
+		t.Fatal("providers_list tool is not registered")
+	}
+
+	if !strings.Contains(tool.Tool.Description, "default, staging") {
+		t.Errorf("expected the description to list the profiles, got %q", tool.Tool.Description)
+	}
+
+	tests := []struct {
+		name      string
+		arguments string
+		wantError bool
+	}{
+		{name: "default profile", arguments: `{}`},
+		{name: "named profile", arguments: `{"profile":"default"}`},
+		{name: "unknown profile", arguments: `{"profile":"production"}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := mcpServer.HandleMessage(context.Background(), json.RawMessage(
+				`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"providers_list","arguments":`+
+					tt.arguments+`}}`))
+
+			result, ok := resp.(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("expected a result, got %#v", resp)
+			}
+
+			callResult, ok := result.Result.(*mcp.CallToolResult)
+			if !ok {
+				t.Fatalf("unexpected tool result: %#v", result.Result)
+			}
+
+			if callResult.IsError != tt.wantError {
+				t.Errorf("expected is_error %v, got %v: %#v", tt.wantError, callResult.IsError, callResult.Content)
+			}
+		})
+	}
+}
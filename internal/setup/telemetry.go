@@ -0,0 +1,66 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tracing"
+)
+
+// Telemetry records what the server does. Metrics are always recorded, and traces when tracing is enabled.
+type Telemetry struct {
+	Metrics *metrics.Metrics
+
+	// Tracer is nil when tracing is disabled.
+	Tracer *tracing.Tracer
+}
+
+// NewTelemetry sets up metrics and, if enabled, starts exporting traces in the background.
+func NewTelemetry(cfg config.App) (Telemetry, error) {
+	telemetry := Telemetry{
+		Metrics: metrics.New(),
+		Tracer:  nil,
+	}
+
+	if !cfg.Tracing.Enabled() {
+		return telemetry, nil
+	}
+
+	tracer, err := tracing.New(cfg.Tracing, cfg.AppVersion)
+	if err != nil {
+		return Telemetry{}, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	slog.Info("exporting traces", "exporter", cfg.Tracing.Exporter)
+
+	telemetry.Tracer = tracer
+
+	return telemetry, nil
+}
+
+// Shutdown exports the traces that haven't been exported yet.
+func (t Telemetry) Shutdown(ctx context.Context) {
+	if t.Tracer == nil {
+		return
+	}
+
+	if err := t.Tracer.Shutdown(ctx); err != nil {
+		slog.Warn("tracing shutdown error", "error", err)
+	}
+}
+
+// toolMiddlewares returns the middleware that every tool call flows through, in order. Tracing comes first, so that
+// tool call logs carry the trace ID.
+func (t Telemetry) toolMiddlewares() []middleware.Middleware {
+	var middlewares []middleware.Middleware
+
+	if t.Tracer != nil {
+		middlewares = append(middlewares, middleware.WithToolCallTracing(t.Tracer))
+	}
+
+	return append(middlewares, middleware.WithToolCallLogging, middleware.WithToolCallMetrics(t.Metrics))
+}
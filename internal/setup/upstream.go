@@ -0,0 +1,12 @@
+package setup
+
+import (
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+)
+
+// Upstream is what every HTTP client of Portkey shares: the telemetry that records its requests, and the circuit
+// breakers that fail them fast while Portkey is down.
+type Upstream struct {
+	Telemetry Telemetry
+	Breakers  *breaker.Breakers
+}
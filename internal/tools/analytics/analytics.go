@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+)
+
+const (
+	// Tool arguments shared by all analytics tools.
+	toolArgTimeOfGenerationMin = "time_of_generation_min"
+	toolArgTimeOfGenerationMax = "time_of_generation_max"
+	toolArgWorkspaceSlug       = "workspace_slug"
+	toolArgAIModel             = "ai_model"
+	toolArgVirtualKeys         = "virtual_keys"
+	toolArgConfigs             = "configs"
+	toolArgAPIKeyIDs           = "api_key_ids"
+	toolArgPromptSlug          = "prompt_slug"
+	toolArgStatusCode          = "status_code"
+	toolArgMetadata            = "metadata"
+	toolArgSummarize           = "summarize"
+
+	// Portkey API query parameters.
+	apiParamTimeOfGenerationMin = "time_of_generation_min"
+	apiParamTimeOfGenerationMax = "time_of_generation_max"
+	apiParamWorkspaceSlug       = "workspace_slug"
+	apiParamAIModel             = "ai_org_model"
+	apiParamVirtualKeys         = "virtual_keys"
+	apiParamConfigs             = "configs"
+	apiParamAPIKeyIDs           = "api_key_ids"
+	apiParamPromptSlug          = "prompt_slug"
+	apiParamStatusCode          = "status_code"
+	apiParamMetadata            = "metadata"
+
+	errTextInternalError = "internal error while processing request"
+)
+
+var (
+	ErrTimeRangeRequired = fmt.Errorf("%s and %s are required", toolArgTimeOfGenerationMin,
+		toolArgTimeOfGenerationMax)
+	ErrInvalidTimeRange = fmt.Errorf("%s must be before %s", toolArgTimeOfGenerationMin, toolArgTimeOfGenerationMax)
+	errMarshalMetadata  = errors.New("failed to marshal metadata filter")
+)
+
+func withFilterArguments() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(toolArgTimeOfGenerationMin,
+			mcp.Required(),
+			mcp.Description("Start of the time range, in RFC 3339 format (e.g. '2025-01-02T15:04:05Z')."),
+		),
+		mcp.WithString(toolArgTimeOfGenerationMax,
+			mcp.Required(),
+			mcp.Description("End of the time range, in RFC 3339 format."),
+		),
+		mcp.WithString(toolArgWorkspaceSlug,
+			mcp.Description("Optional. Only include requests from this workspace."),
+		),
+		mcp.WithString(toolArgAIModel,
+			mcp.Description("Optional. Only include requests to this model (e.g. 'gpt-4o')."),
+		),
+		mcp.WithString(toolArgVirtualKeys,
+			mcp.Description("Optional. Only include requests made with these virtual key slugs, comma-separated."),
+		),
+		mcp.WithString(toolArgConfigs,
+			mcp.Description("Optional. Only include requests made with these config slugs, comma-separated."),
+		),
+		mcp.WithString(toolArgAPIKeyIDs,
+			mcp.Description("Optional. Only include requests made with these API key IDs, comma-separated."),
+		),
+		mcp.WithString(toolArgPromptSlug,
+			mcp.Description("Optional. Only include requests made with this prompt slug."),
+		),
+		mcp.WithString(toolArgStatusCode,
+			mcp.Description("Optional. Only include requests with this HTTP status code. Multiple codes may be "+
+				"comma-separated (e.g. '429,500')."),
+		),
+		mcp.WithObject(toolArgMetadata,
+			mcp.Description("Optional. Only include requests with this metadata. The object should be a JSON object "+
+				"with key-value pairs of string metadata keys to string values."),
+		),
+	}
+}
+
+func getFilterArguments(request mcp.CallToolRequest) (Request, error) {
+	minTime, err := tools.ParseTime(request, toolArgTimeOfGenerationMin)
+	if err != nil {
+		return Request{}, err
+	}
+
+	maxTime, err := tools.ParseTime(request, toolArgTimeOfGenerationMax)
+	if err != nil {
+		return Request{}, err
+	}
+
+	if minTime == nil || maxTime == nil {
+		return Request{}, ErrTimeRangeRequired
+	}
+
+	if !minTime.Before(*maxTime) {
+		return Request{}, ErrInvalidTimeRange
+	}
+
+	metadata, err := tools.ParseStringMap(request, toolArgMetadata)
+	if err != nil {
+		return Request{}, err
+	}
+
+	return Request{
+		TimeOfGenerationMin: *minTime,
+		TimeOfGenerationMax: *maxTime,
+		WorkspaceSlug:       mcp.ParseString(request, toolArgWorkspaceSlug, ""),
+		AIModel:             mcp.ParseString(request, toolArgAIModel, ""),
+		VirtualKeys:         mcp.ParseString(request, toolArgVirtualKeys, ""),
+		Configs:             mcp.ParseString(request, toolArgConfigs, ""),
+		APIKeyIDs:           mcp.ParseString(request, toolArgAPIKeyIDs, ""),
+		PromptSlug:          mcp.ParseString(request, toolArgPromptSlug, ""),
+		StatusCode:          mcp.ParseString(request, toolArgStatusCode, ""),
+		Metadata:            metadata,
+	}, nil
+}
+
+// createURL builds the URL of an analytics endpoint, e.g. "graphs/cost", with the provided filters.
+func createURL(baseURL string, endpoint string, req Request) (string, error) {
+	values := url.Values{}
+	values.Add(apiParamTimeOfGenerationMin, req.TimeOfGenerationMin.Format(time.RFC3339))
+	values.Add(apiParamTimeOfGenerationMax, req.TimeOfGenerationMax.Format(time.RFC3339))
+
+	optional := []struct {
+		param string
+		value string
+	}{
+		{apiParamWorkspaceSlug, req.WorkspaceSlug},
+		{apiParamAIModel, req.AIModel},
+		{apiParamVirtualKeys, req.VirtualKeys},
+		{apiParamConfigs, req.Configs},
+		{apiParamAPIKeyIDs, req.APIKeyIDs},
+		{apiParamPromptSlug, req.PromptSlug},
+		{apiParamStatusCode, req.StatusCode},
+	}
+
+	for _, o := range optional {
+		if o.value != "" {
+			values.Add(o.param, o.value)
+		}
+	}
+
+	if len(req.Metadata) > 0 {
+		metadata, err := json.Marshal(req.Metadata)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", errMarshalMetadata, err)
+		}
+
+		values.Add(apiParamMetadata, string(metadata))
+	}
+
+	return fmt.Sprintf("%s/analytics/%s?%s", baseURL, endpoint, values.Encode()), nil
+}
@@ -0,0 +1,20 @@
+package analytics
+
+import "time"
+
+// Request represents the query parameters shared by the Portkey Analytics APIs.
+type Request struct {
+	// Required arguments
+	TimeOfGenerationMin time.Time `json:"time_of_generation_min"`
+	TimeOfGenerationMax time.Time `json:"time_of_generation_max"`
+
+	// Optional arguments
+	WorkspaceSlug string            `json:"workspace_slug,omitempty"`
+	AIModel       string            `json:"ai_org_model,omitempty"`
+	VirtualKeys   string            `json:"virtual_keys,omitempty"`
+	Configs       string            `json:"configs,omitempty"`
+	APIKeyIDs     string            `json:"api_key_ids,omitempty"`
+	PromptSlug    string            `json:"prompt_slug,omitempty"`
+	StatusCode    string            `json:"status_code,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
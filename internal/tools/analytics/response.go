@@ -0,0 +1,267 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GraphResponse represents the full response structure from the Portkey Analytics Graphs APIs. The summary and data
+// point fields differ by metric, so they are kept as name/value pairs.
+type GraphResponse struct {
+	Object     string      `json:"object"`
+	Summary    Metrics     `json:"summary"`
+	DataPoints []DataPoint `json:"data_points"`
+}
+
+// Metrics holds the numeric fields of an analytics object. Fields with non-numeric values are dropped.
+type Metrics map[string]float64
+
+// DataPoint is a single point of an analytics time series.
+type DataPoint struct {
+	Timestamp time.Time
+	Values    map[string]float64
+}
+
+// GroupResponse represents the full response structure from the Portkey Analytics Groups APIs.
+type GroupResponse struct {
+	Object string  `json:"object"`
+	Total  int     `json:"total"`
+	Data   []Group `json:"data"`
+}
+
+// Group is a single row of grouped analytics, e.g. the requests and cost of one model.
+type Group struct {
+	// Key is the value that was grouped by, e.g. the model name.
+	Key        string
+	Metrics    map[string]float64
+	Attributes map[string]string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal metrics: %w", err)
+	}
+
+	*m = make(Metrics, len(raw))
+
+	for key, value := range raw {
+		if number, ok := value.(float64); ok {
+			(*m)[key] = number
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Numeric fields other than the timestamp become values.
+func (dp *DataPoint) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal data point: %w", err)
+	}
+
+	dp.Values = make(map[string]float64, len(raw))
+
+	for key, value := range raw {
+		if key == "timestamp" {
+			str, _ := value.(string)
+
+			ts, err := time.Parse(time.RFC3339, str)
+			if err != nil {
+				return fmt.Errorf("failed to parse data point timestamp: %w", err)
+			}
+
+			dp.Timestamp = ts
+
+			continue
+		}
+
+		if number, ok := value.(float64); ok {
+			dp.Values[key] = number
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, flattening values next to the timestamp.
+func (dp DataPoint) MarshalJSON() ([]byte, error) {
+	flat := make(map[string]any, len(dp.Values)+1)
+	for key, value := range dp.Values {
+		flat[key] = value
+	}
+
+	flat["timestamp"] = dp.Timestamp
+
+	data, err := json.Marshal(flat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data point: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Numeric fields become metrics and string fields become
+// attributes. The key is filled in by the caller, which knows what was grouped by.
+func (g *Group) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+
+	g.Metrics = make(map[string]float64, len(raw))
+	g.Attributes = make(map[string]string, len(raw))
+
+	for key, value := range raw {
+		switch v := value.(type) {
+		case float64:
+			g.Metrics[key] = v
+		case string:
+			if key != "object" {
+				g.Attributes[key] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, flattening metrics and attributes next to the key.
+func (g Group) MarshalJSON() ([]byte, error) {
+	flat := make(map[string]any, len(g.Metrics)+len(g.Attributes)+1)
+	for key, value := range g.Attributes {
+		flat[key] = value
+	}
+
+	for key, value := range g.Metrics {
+		flat[key] = value
+	}
+
+	flat["key"] = g.Key
+
+	data, err := json.Marshal(flat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal group: %w", err)
+	}
+
+	return data, nil
+}
+
+// GraphSummary is returned instead of the full time series when a summary is requested.
+type GraphSummary struct {
+	Metric         string                `json:"metric"`
+	Summary        Metrics               `json:"summary"`
+	Fields         map[string]FieldStats `json:"fields"`
+	DataPointCount int                   `json:"data_point_count"`
+}
+
+// FieldStats describes one data point field across a whole time series.
+type FieldStats struct {
+	Total float64   `json:"total"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Avg   float64   `json:"avg"`
+	Peak  time.Time `json:"peak_at"`
+}
+
+// GroupSummary is returned instead of every group when a summary is requested.
+type GroupSummary struct {
+	GroupBy    string             `json:"group_by"`
+	SortBy     string             `json:"sort_by"`
+	Totals     map[string]float64 `json:"totals"`
+	Top        []Group            `json:"top"`
+	Other      *Group             `json:"other,omitempty"`
+	GroupCount int                `json:"group_count"`
+}
+
+// The output schemas below are written by hand, because data points and groups are flattened when marshaled, which
+// schemas generated from Go types can't describe.
+
+//nolint:gochecknoglobals
+var graphOutputSchema = json.RawMessage(`{
+	"type": "object",
+	"anyOf": [
+		{
+			"type": "object",
+			"properties": {
+				"object": {"type": "string"},
+				"summary": {"$ref": "#/$defs/metrics"},
+				"data_points": {
+					"type": ["null", "array"],
+					"items": {
+						"type": "object",
+						"properties": {"timestamp": {"type": "string"}},
+						"additionalProperties": {"type": "number"},
+						"required": ["timestamp"]
+					}
+				}
+			},
+			"required": ["object", "summary", "data_points"]
+		},
+		{
+			"type": "object",
+			"properties": {
+				"metric": {"type": "string"},
+				"summary": {"$ref": "#/$defs/metrics"},
+				"fields": {
+					"type": ["null", "object"],
+					"additionalProperties": {
+						"type": "object",
+						"properties": {
+							"total": {"type": "number"},
+							"min": {"type": "number"},
+							"max": {"type": "number"},
+							"avg": {"type": "number"},
+							"peak_at": {"type": "string"}
+						},
+						"required": ["total", "min", "max", "avg", "peak_at"]
+					}
+				},
+				"data_point_count": {"type": "integer"}
+			},
+			"required": ["metric", "summary", "fields", "data_point_count"]
+		}
+	],
+	"$defs": {
+		"metrics": {"type": ["null", "object"], "additionalProperties": {"type": "number"}}
+	}
+}`)
+
+//nolint:gochecknoglobals
+var groupOutputSchema = json.RawMessage(`{
+	"type": "object",
+	"anyOf": [
+		{
+			"type": "object",
+			"properties": {
+				"object": {"type": "string"},
+				"total": {"type": "integer"},
+				"data": {"type": ["null", "array"], "items": {"$ref": "#/$defs/group"}}
+			},
+			"required": ["object", "total", "data"]
+		},
+		{
+			"type": "object",
+			"properties": {
+				"group_by": {"type": "string"},
+				"sort_by": {"type": "string"},
+				"totals": {"type": ["null", "object"], "additionalProperties": {"type": "number"}},
+				"top": {"type": ["null", "array"], "items": {"$ref": "#/$defs/group"}},
+				"other": {"$ref": "#/$defs/group"},
+				"group_count": {"type": "integer"}
+			},
+			"required": ["group_by", "sort_by", "totals", "top", "group_count"]
+		}
+	],
+	"$defs": {
+		"group": {
+			"type": "object",
+			"properties": {"key": {"type": "string"}},
+			"additionalProperties": {"type": ["string", "number"]},
+			"required": ["key"]
+		}
+	}
+}`)
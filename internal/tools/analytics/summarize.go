@@ -0,0 +1,142 @@
+package analytics
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Granularities that a time series can be re-bucketed into.
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+	GranularityWeek = "week"
+)
+
+const otherGroupKey = "other"
+
+// Rebucket merges data points into hourly, daily or weekly buckets (in UTC, with weeks starting on Monday), summing
+// their values. It is only meant for additive metrics, like cost: latency percentiles, rates and distinct user counts
+// can't be recovered from the values of the data points in a bucket.
+func Rebucket(points []DataPoint, granularity string) []DataPoint {
+	buckets := make(map[time.Time]DataPoint)
+
+	for _, point := range points {
+		start := truncate(point.Timestamp, granularity)
+
+		b, ok := buckets[start]
+		if !ok {
+			b = DataPoint{Timestamp: start, Values: make(map[string]float64, len(point.Values))}
+			buckets[start] = b
+		}
+
+		for key, value := range point.Values {
+			b.Values[key] += value
+		}
+	}
+
+	rebucketed := make([]DataPoint, 0, len(buckets))
+
+	for _, start := range slices.SortedFunc(maps.Keys(buckets), time.Time.Compare) {
+		rebucketed = append(rebucketed, buckets[start])
+	}
+
+	return rebucketed
+}
+
+func truncate(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+
+	switch granularity {
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	case GranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7 //nolint:mnd
+
+		return day.AddDate(0, 0, -daysSinceMonday)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// SummarizeGraph reduces a time series to per-field totals, extremes, averages and the time of each field's peak.
+// Sparse fields, which only some data points have, are averaged over the data points that have them.
+func SummarizeGraph(metric string, resp GraphResponse) GraphSummary {
+	fields := make(map[string]FieldStats)
+	count := make(map[string]int)
+
+	for _, point := range resp.DataPoints {
+		for key, value := range point.Values {
+			stats, seen := fields[key]
+			if !seen || value < stats.Min {
+				stats.Min = value
+			}
+
+			if !seen || value > stats.Max {
+				stats.Max = value
+				stats.Peak = point.Timestamp
+			}
+
+			stats.Total += value
+			fields[key] = stats
+			count[key]++
+		}
+	}
+
+	for key, stats := range fields {
+		stats.Avg = stats.Total / float64(count[key])
+		fields[key] = stats
+	}
+
+	return GraphSummary{
+		Metric:         metric,
+		Summary:        resp.Summary,
+		Fields:         fields,
+		DataPointCount: len(resp.DataPoints),
+	}
+}
+
+// SummarizeGroups keeps the top N groups by the sortBy metric, and folds the remaining groups into a single "other"
+// group. Totals are computed across every group.
+func SummarizeGroups(groupBy string, sortBy string, groups []Group, topN int) GroupSummary {
+	sorted := slices.Clone(groups)
+	slices.SortStableFunc(sorted, func(a, b Group) int {
+		return cmp.Compare(b.Metrics[sortBy], a.Metrics[sortBy])
+	})
+
+	totals := make(map[string]float64)
+
+	for _, group := range sorted {
+		for key, value := range group.Metrics {
+			totals[key] += value
+		}
+	}
+
+	summary := GroupSummary{
+		GroupBy:    groupBy,
+		SortBy:     sortBy,
+		Totals:     totals,
+		Top:        sorted,
+		Other:      nil,
+		GroupCount: len(groups),
+	}
+
+	if topN <= 0 || len(sorted) <= topN {
+		return summary
+	}
+
+	summary.Top = sorted[:topN]
+	other := Group{Key: otherGroupKey, Metrics: make(map[string]float64), Attributes: nil}
+
+	for _, group := range sorted[topN:] {
+		for key, value := range group.Metrics {
+			other.Metrics[key] += value
+		}
+	}
+
+	summary.Other = &other
+
+	return summary
+}
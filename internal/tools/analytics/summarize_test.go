@@ -0,0 +1,85 @@
+package analytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/analytics"
+)
+
+func TestRebucket(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	points := []analytics.DataPoint{
+		{Timestamp: day.Add(time.Hour), Values: map[string]float64{"total": 1}},
+		{Timestamp: day.Add(2 * time.Hour), Values: map[string]float64{"total": 3}},
+		{Timestamp: day.Add(25 * time.Hour), Values: map[string]float64{"total": 5}},
+	}
+
+	daily := analytics.Rebucket(points, analytics.GranularityDay)
+	if len(daily) != 2 || daily[0].Values["total"] != 4 || daily[1].Values["total"] != 5 {
+		t.Errorf("unexpected daily rebucketing: %+v", daily)
+	}
+
+	// 2025-01-02 is a Thursday, so both days fall in the week starting Monday 2024-12-30.
+	weekly := analytics.Rebucket(points, analytics.GranularityWeek)
+	if len(weekly) != 1 || !weekly[0].Timestamp.Equal(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected weekly rebucketing: %+v", weekly)
+	}
+}
+
+func TestSummarizeGraphAveragesSparseFields(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	//nolint:exhaustruct
+	resp := analytics.GraphResponse{
+		DataPoints: []analytics.DataPoint{
+			{Timestamp: day, Values: map[string]float64{"total": 2, "503": 4}},
+			{Timestamp: day.Add(time.Hour), Values: map[string]float64{"total": 4}},
+			{Timestamp: day.Add(2 * time.Hour), Values: map[string]float64{"total": 6, "503": 8}},
+			{Timestamp: day.Add(3 * time.Hour), Values: map[string]float64{"total": 8}},
+		},
+	}
+
+	summary := analytics.SummarizeGraph("errors", resp)
+
+	if got := summary.Fields["total"].Avg; got != 5 {
+		t.Errorf("expected total to average 5 over every data point, got %g", got)
+	}
+
+	if got := summary.Fields["503"].Avg; got != 6 {
+		t.Errorf("expected 503 to average 6 over the data points that have it, got %g", got)
+	}
+
+	if got := summary.Fields["503"]; got.Total != 12 || got.Min != 4 || got.Max != 8 {
+		t.Errorf("unexpected stats for sparse field: %+v", got)
+	}
+}
+
+func TestSummarizeGroups(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct
+	groups := []analytics.Group{
+		{Key: "gpt-4o-mini", Metrics: map[string]float64{"cost": 2, "requests": 50}},
+		{Key: "gpt-4o", Metrics: map[string]float64{"cost": 10, "requests": 5}},
+		{Key: "claude", Metrics: map[string]float64{"cost": 4, "requests": 10}},
+	}
+
+	summary := analytics.SummarizeGroups("ai_models", "cost", groups, 2)
+
+	if len(summary.Top) != 2 || summary.Top[0].Key != "gpt-4o" || summary.Top[1].Key != "claude" {
+		t.Errorf("unexpected top groups: %+v", summary.Top)
+	}
+
+	if summary.Other == nil || summary.Other.Metrics["requests"] != 50 {
+		t.Errorf("unexpected other group: %+v", summary.Other)
+	}
+
+	if summary.Totals["cost"] != 16 || summary.GroupCount != 3 {
+		t.Errorf("unexpected totals: %+v", summary.Totals)
+	}
+}
@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	graphToolName = "analytics_graph"
+
+	// Tool arguments.
+	toolArgMetric      = "metric"
+	toolArgGranularity = "granularity"
+)
+
+// graphMetric describes a Portkey analytics graph.
+type graphMetric struct {
+	endpoint string
+
+	// additive is true if data points can be summed when merging them, e.g. cost. Other metrics, like latency
+	// percentiles, rates and distinct users, can't be re-bucketed.
+	additive bool
+}
+
+//nolint:gochecknoglobals
+var graphMetrics = map[string]graphMetric{
+	"cache_hit_rate":     {endpoint: "graphs/cache/hit-rate", additive: false},
+	"cache_latency":      {endpoint: "graphs/cache/latency", additive: false},
+	"cost":               {endpoint: "graphs/cost", additive: true},
+	"error_rate":         {endpoint: "graphs/errors/rate", additive: false},
+	"error_status_codes": {endpoint: "graphs/errors/status-codes", additive: true},
+	"errors":             {endpoint: "graphs/errors", additive: true},
+	"latency":            {endpoint: "graphs/latency", additive: false},
+	"requests":           {endpoint: "graphs/requests", additive: true},
+	"rescued_requests":   {endpoint: "graphs/requests/rescued", additive: true},
+	"tokens":             {endpoint: "graphs/tokens", additive: true},
+	"users":              {endpoint: "graphs/users", additive: false},
+}
+
+var (
+	ErrMetricRequired     = errors.New("metric is required")
+	ErrUnknownMetric      = errors.New("unknown metric")
+	ErrUnknownGranularity = errors.New("unknown granularity")
+	ErrNotAdditive        = fmt.Errorf("%s can only be used with metrics that can be summed", toolArgGranularity)
+)
+
+type graphToolArgs struct {
+	metric      string
+	filters     Request
+	granularity string
+	summarize   bool
+}
+
+func NewGraphTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Get a Portkey analytics time series and its summary, for cost, tokens, requests, latency " +
+		"percentiles, errors, cache hit rate and more, over a time range and with optional filters. Set summarize " +
+		"to true to get totals, extremes and averages instead of every data point."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Graph Analytics"),
+		mcp.WithRawOutputSchema(graphOutputSchema),
+		mcp.WithString(toolArgMetric,
+			mcp.Required(),
+			mcp.Description("The metric to graph."),
+			mcp.Enum(slices.Sorted(maps.Keys(graphMetrics))...),
+		),
+		mcp.WithString(toolArgGranularity,
+			mcp.Description(fmt.Sprintf("Optional. Merge data points into buckets of this size (in UTC), summing "+
+				"them. Only for metrics that can be summed: %s. Latency percentiles, rates and distinct users can't "+
+				"be merged from Portkey's data points. If omitted, Portkey's own bucket size is used.",
+				strings.Join(additiveMetrics(), ", "))),
+			mcp.Enum(GranularityHour, GranularityDay, GranularityWeek),
+		),
+		mcp.WithBoolean(toolArgSummarize,
+			mcp.Description("Optional. If true, return per-field totals, minimums, maximums and averages instead of "+
+				"the full time series. Defaults to false."),
+		),
+	}
+
+	graphTool := mcp.NewTool(graphToolName, append(opts, withFilterArguments()...)...)
+
+	return tools.Tuple{
+		Tool:    &graphTool,
+		Handler: graphHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// graphHandler calls a Portkey Analytics Graphs API and returns the (optionally summarized) result.
+func graphHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getGraphToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		metric := graphMetrics[args.metric]
+
+		apiURL, err := createURL(portkey.BaseURL, metric.endpoint, args.filters)
+		if err != nil {
+			lgr.Error("failed to create url", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError), nil
+		}
+
+		var portkeyResp GraphResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    apiURL,
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if args.granularity != "" {
+			portkeyResp.DataPoints = Rebucket(portkeyResp.DataPoints, args.granularity)
+		}
+
+		if args.summarize {
+			return tools.NewToolResultJSON(lgr, SummarizeGraph(args.metric, portkeyResp)), nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getGraphToolArguments(request mcp.CallToolRequest) (graphToolArgs, error) {
+	metric := mcp.ParseString(request, toolArgMetric, "")
+	if metric == "" {
+		return graphToolArgs{}, ErrMetricRequired
+	}
+
+	if _, ok := graphMetrics[metric]; !ok {
+		return graphToolArgs{}, fmt.Errorf("%w: %q, must be one of: %s", ErrUnknownMetric, metric,
+			strings.Join(slices.Sorted(maps.Keys(graphMetrics)), ", "))
+	}
+
+	granularity := mcp.ParseString(request, toolArgGranularity, "")
+	switch granularity {
+	case "", GranularityHour, GranularityDay, GranularityWeek:
+	default:
+		return graphToolArgs{}, fmt.Errorf("%w: %q, must be one of: %s, %s, %s", ErrUnknownGranularity, granularity,
+			GranularityHour, GranularityDay, GranularityWeek)
+	}
+
+	if granularity != "" && !graphMetrics[metric].additive {
+		return graphToolArgs{}, fmt.Errorf("%w: %q, must be one of: %s", ErrNotAdditive, metric,
+			strings.Join(additiveMetrics(), ", "))
+	}
+
+	filters, err := getFilterArguments(request)
+	if err != nil {
+		return graphToolArgs{}, err
+	}
+
+	return graphToolArgs{
+		metric:      metric,
+		filters:     filters,
+		granularity: granularity,
+		summarize:   mcp.ParseBoolean(request, toolArgSummarize, false),
+	}, nil
+}
+
+// additiveMetrics returns the sorted names of the metrics that can be re-bucketed.
+func additiveMetrics() []string {
+	var names []string
+
+	for _, name := range slices.Sorted(maps.Keys(graphMetrics)) {
+		if graphMetrics[name].additive {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,79 @@
+package analytics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/analytics"
+)
+
+// TestGraphGranularity makes sure that only metrics that can be summed are re-bucketed, and that the others are
+// rejected rather than averaged.
+func TestGraphGranularity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		metric    string
+		wantError bool
+	}{
+		{metric: "cost", wantError: false},
+		{metric: "requests", wantError: false},
+		{metric: "latency", wantError: true},
+		{metric: "cache_hit_rate", wantError: true},
+		{metric: "error_rate", wantError: true},
+		{metric: "users", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				_, _ = w.Write([]byte(`{"object":"analytics-graph","summary":{"total":6},"data_points":[` +
+					`{"timestamp":"2025-01-02T01:00:00Z","total":1},{"timestamp":"2025-01-02T02:00:00Z","total":5}]}`))
+			}))
+			defer srv.Close()
+
+			tool := analytics.NewGraphTool(
+				config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: srv.URL}, //nolint:exhaustruct
+				config.BaseTool{Description: "", Enabled: true},
+			)
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = map[string]any{
+				"metric":                 tt.metric,
+				"granularity":            analytics.GranularityDay,
+				"time_of_generation_min": "2025-01-02T00:00:00Z",
+				"time_of_generation_max": "2025-01-03T00:00:00Z",
+			}
+
+			result, err := tool.Handler(t.Context(), request)
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+
+			if tt.wantError {
+				text, _ := result.Content[0].(mcp.TextContent)
+				if !result.IsError || !strings.Contains(text.Text, analytics.ErrNotAdditive.Error()) || calls.Load() != 0 {
+					t.Errorf("expected %q to be rejected without calling portkey, got %#v", tt.metric, result)
+				}
+
+				return
+			}
+
+			resp, ok := result.StructuredContent.(analytics.GraphResponse)
+			if !ok || len(resp.DataPoints) != 1 || resp.DataPoints[0].Values["total"] != 6 {
+				t.Errorf("expected one daily data point with a total of 6, got %#v", result.StructuredContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	groupToolName = "analytics_group"
+
+	// Tool arguments.
+	toolArgGroupBy     = "group_by"
+	toolArgMetadataKey = "metadata_key"
+	toolArgTopN        = "top_n"
+	toolArgSortBy      = "sort_by"
+
+	groupByAIModels = "ai_models"
+	groupByUsers    = "users"
+	groupByMetadata = "metadata"
+
+	defaultSortBy = "cost"
+	defaultTopN   = 10
+)
+
+var (
+	ErrGroupByRequired     = errors.New("group_by is required")
+	ErrUnknownGroupBy      = errors.New("unknown group_by")
+	ErrMetadataKeyRequired = fmt.Errorf("metadata_key is required when grouping by %s", groupByMetadata)
+	ErrInvalidTopN         = fmt.Errorf("%s must be a positive integer", toolArgTopN)
+)
+
+type groupToolArgs struct {
+	groupBy     string
+	metadataKey string
+	filters     Request
+	summarize   bool
+	sortBy      string
+	topN        int
+}
+
+func NewGroupTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Get Portkey analytics grouped by model, user or a metadata key (e.g. a team metadata key), over " +
+		"a time range and with optional filters. Each group includes metrics like requests and cost. Set summarize " +
+		"to true to get totals and only the top groups. Portkey can't group by workspace; to compare workspaces, " +
+		"call this tool once per workspace_slug, or group by a metadata key that your requests set per workspace."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Group Analytics"),
+		mcp.WithRawOutputSchema(groupOutputSchema),
+		mcp.WithString(toolArgGroupBy,
+			mcp.Required(),
+			mcp.Description("What to group by. Portkey's analytics API has no workspace grouping, so workspaces "+
+				"are compared with the workspace_slug filter instead."),
+			mcp.Enum(groupByAIModels, groupByUsers, groupByMetadata),
+		),
+		mcp.WithString(toolArgMetadataKey,
+			mcp.Description(fmt.Sprintf("The metadata key to group by. Required when %s is %s.",
+				toolArgGroupBy, groupByMetadata)),
+		),
+		mcp.WithBoolean(toolArgSummarize,
+			mcp.Description("Optional. If true, return totals across all groups, the top groups, and a single "+
+				"'other' group with the remaining groups' metrics summed. Defaults to false."),
+		),
+		mcp.WithString(toolArgSortBy,
+			mcp.Description(fmt.Sprintf("Optional. The metric used to rank groups when summarizing, e.g. 'cost' or "+
+				"'requests'. Defaults to '%s'.", defaultSortBy)),
+		),
+		mcp.WithNumber(toolArgTopN,
+			mcp.Description(fmt.Sprintf("Optional. The number of top groups to keep when summarizing. Defaults to %d.",
+				defaultTopN)),
+		),
+	}
+
+	groupTool := mcp.NewTool(groupToolName, append(opts, withFilterArguments()...)...)
+
+	return tools.Tuple{
+		Tool:    &groupTool,
+		Handler: groupHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// groupHandler calls a Portkey Analytics Groups API and returns the (optionally summarized) result.
+func groupHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getGroupToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		endpoint, keyField := groupEndpoint(args)
+
+		apiURL, err := createURL(portkey.BaseURL, endpoint, args.filters)
+		if err != nil {
+			lgr.Error("failed to create url", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError), nil
+		}
+
+		var portkeyResp GroupResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    apiURL,
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		for i := range portkeyResp.Data {
+			portkeyResp.Data[i].Key = portkeyResp.Data[i].Attributes[keyField]
+			delete(portkeyResp.Data[i].Attributes, keyField)
+		}
+
+		if args.summarize {
+			return tools.NewToolResultJSON(lgr,
+				SummarizeGroups(args.groupBy, args.sortBy, portkeyResp.Data, args.topN)), nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+// groupEndpoint returns the analytics endpoint for a grouping, and the response field that holds each group's key.
+func groupEndpoint(args groupToolArgs) (string, string) {
+	switch args.groupBy {
+	case groupByUsers:
+		return "groups/users", "user"
+	case groupByMetadata:
+		return "groups/metadata/" + url.PathEscape(args.metadataKey), "metadata_value"
+	default:
+		return "groups/ai-models", "ai_model"
+	}
+}
+
+func getGroupToolArguments(request mcp.CallToolRequest) (groupToolArgs, error) {
+	groupBy := mcp.ParseString(request, toolArgGroupBy, "")
+
+	switch groupBy {
+	case "":
+		return groupToolArgs{}, ErrGroupByRequired
+	case groupByAIModels, groupByUsers, groupByMetadata:
+	default:
+		return groupToolArgs{}, fmt.Errorf("%w: %q, must be one of: %s, %s, %s", ErrUnknownGroupBy, groupBy,
+			groupByAIModels, groupByUsers, groupByMetadata)
+	}
+
+	metadataKey := mcp.ParseString(request, toolArgMetadataKey, "")
+	if groupBy == groupByMetadata && metadataKey == "" {
+		return groupToolArgs{}, ErrMetadataKeyRequired
+	}
+
+	topN := mcp.ParseInt(request, toolArgTopN, defaultTopN)
+	if topN <= 0 {
+		return groupToolArgs{}, ErrInvalidTopN
+	}
+
+	filters, err := getFilterArguments(request)
+	if err != nil {
+		return groupToolArgs{}, err
+	}
+
+	return groupToolArgs{
+		groupBy:     groupBy,
+		metadataKey: metadataKey,
+		filters:     filters,
+		summarize:   mcp.ParseBoolean(request, toolArgSummarize, false),
+		sortBy:      mcp.ParseString(request, toolArgSortBy, defaultSortBy),
+		topN:        topN,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package tools
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// The annotations below tell clients what a tool does to the Portkey account, e.g. so that read-only calls can be
+// auto-approved. Every tool only talks to the configured Portkey account, so none of them is open-world.
+
+// ReadOnlyAnnotation annotates a tool that doesn't change anything.
+func ReadOnlyAnnotation(title string) mcp.ToolOption {
+	return annotation(title, true, false, true)
+}
+
+// CreateAnnotation annotates a tool that creates something new on every call.
+func CreateAnnotation(title string) mcp.ToolOption {
+	return annotation(title, false, false, false)
+}
+
+// UpdateAnnotation annotates a tool that changes only the settings it is given, leaving the others as they are.
+// Repeating the call has no further effect.
+func UpdateAnnotation(title string) mcp.ToolOption {
+	return annotation(title, false, false, true)
+}
+
+// DestructiveAnnotation annotates a tool that deletes something, or that can lose existing settings or data, e.g. by
+// replacing a list or resetting usage. Repeating the call has no further effect.
+func DestructiveAnnotation(title string) mcp.ToolOption {
+	return annotation(title, false, true, true)
+}
+
+func annotation(title string, readOnly, destructive, idempotent bool) mcp.ToolOption {
+	openWorld := false
+
+	return mcp.WithToolAnnotation(mcp.ToolAnnotation{
+		Title:           title,
+		ReadOnlyHint:    &readOnly,
+		DestructiveHint: &destructive,
+		IdempotentHint:  &idempotent,
+		OpenWorldHint:   &openWorld,
+	})
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	ErrInvalidTime           = errors.New("time must be in RFC 3339 format, e.g. 2025-01-02T15:04:05Z")
+	ErrInvalidNonNegative    = errors.New("value must be a non-negative number")
+	ErrValuesMustBeStrings   = errors.New("all object values must be strings")
+	ErrItemsMustBeStrings    = errors.New("value must be an array of strings")
+	ErrInvalidObjectArgument = errors.New("value must be an object")
+	ErrInvalidArgumentShape  = errors.New("value does not match the expected structure")
+)
+
+// ParseTime parses an optional RFC 3339 timestamp argument. A nil time is returned if the argument is absent.
+func ParseTime(request mcp.CallToolRequest, argName string) (*time.Time, error) {
+	raw := mcp.ParseString(request, argName, "")
+	if raw == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTime, argName)
+	}
+
+	return &t, nil
+}
+
+// ParseNonNegativeFloat parses an optional non-negative number argument. A nil value is returned if the argument is
+// absent.
+func ParseNonNegativeFloat(request mcp.CallToolRequest, argName string) (*float64, error) {
+	if mcp.ParseArgument(request, argName, nil) == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	value := mcp.ParseFloat64(request, argName, -1)
+	if value < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNonNegative, argName)
+	}
+
+	return &value, nil
+}
+
+// ParseStringMap parses an optional object argument whose values must all be strings.
+func ParseStringMap(request mcp.CallToolRequest, argName string) (map[string]string, error) {
+	rawValue := mcp.ParseArgument(request, argName, nil)
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	rawMap, ok := rawValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidObjectArgument, argName)
+	}
+
+	result := make(map[string]string, len(rawMap))
+
+	for key, value := range rawMap {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s key %q has type %T", ErrValuesMustBeStrings, argName, key, value)
+		}
+
+		result[key] = str
+	}
+
+	return result, nil
+}
+
+// ParseObject parses an optional object argument, whose values may be of any type.
+func ParseObject(request mcp.CallToolRequest, argName string) (map[string]any, error) {
+	rawValue := mcp.ParseArgument(request, argName, nil)
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	object, ok := rawValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidObjectArgument, argName)
+	}
+
+	return object, nil
+}
+
+// ParseStringSlice parses an optional array argument whose items must all be strings.
+func ParseStringSlice(request mcp.CallToolRequest, argName string) ([]string, error) {
+	rawValue := mcp.ParseArgument(request, argName, nil)
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	rawArray, ok := rawValue.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrItemsMustBeStrings, argName)
+	}
+
+	result := make([]string, 0, len(rawArray))
+
+	for _, item := range rawArray {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrItemsMustBeStrings, argName)
+		}
+
+		result = append(result, str)
+	}
+
+	return result, nil
+}
+
+// DecodeArgument decodes an optional argument into out, by way of JSON, so that structured arguments (objects and
+// arrays of objects) can be validated against typed structs. Unknown fields are rejected. It reports whether the
+// argument was present.
+func DecodeArgument(request mcp.CallToolRequest, argName string, out any) (bool, error) {
+	rawValue := mcp.ParseArgument(request, argName, nil)
+	if rawValue == nil {
+		return false, nil
+	}
+
+	data, err := json.Marshal(rawValue)
+	if err != nil {
+		return true, fmt.Errorf("%w: %s: %w", ErrInvalidArgumentShape, argName, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(out); err != nil {
+		return true, fmt.Errorf("%w: %s: %w", ErrInvalidArgumentShape, argName, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,95 @@
+package feedback
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+)
+
+const (
+	// Tool arguments shared by the create and update tools.
+	toolArgValue    = "value"
+	toolArgWeight   = "weight"
+	toolArgMetadata = "metadata"
+)
+
+// Portkey accepts integer feedback values in this range, and weights between 0 and 1. Guardrails attach feedback to
+// their outcomes, with the same bounds.
+const (
+	MinValue  = -10
+	MaxValue  = 10
+	MinWeight = 0.0
+	MaxWeight = 1.0
+)
+
+var (
+	ErrValueRequired = errors.New("value is required")
+	ErrInvalidValue  = fmt.Errorf("value must be an integer between %d and %d", MinValue, MaxValue)
+	ErrInvalidWeight = fmt.Errorf("weight must be a number between %g and %g", MinWeight, MaxWeight)
+)
+
+// scoreArgs holds the arguments that describe a feedback score, common to creating and updating feedback.
+type scoreArgs struct {
+	value    int
+	weight   *float64
+	metadata map[string]any
+}
+
+func withScoreArguments() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithNumber(toolArgValue,
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("The feedback score, as an integer between %d (worst) and %d (best).",
+				MinValue, MaxValue)),
+			mcp.Min(MinValue),
+			mcp.Max(MaxValue),
+		),
+		mcp.WithNumber(toolArgWeight,
+			mcp.Description(fmt.Sprintf("Optional. How much this feedback should count, between %g and %g. "+
+				"Portkey defaults to %g.", MinWeight, MaxWeight, MaxWeight)),
+			mcp.Min(MinWeight),
+			mcp.Max(MaxWeight),
+		),
+		mcp.WithObject(toolArgMetadata,
+			mcp.Description("Optional. Additional metadata to store with the feedback, e.g. the evaluator name or "+
+				"the reason for the score."),
+		),
+	}
+}
+
+func getScoreArguments(request mcp.CallToolRequest) (scoreArgs, error) {
+	rawValue := mcp.ParseArgument(request, toolArgValue, nil)
+	if rawValue == nil {
+		return scoreArgs{}, ErrValueRequired
+	}
+
+	value, ok := rawValue.(float64)
+	if !ok || value != float64(int(value)) || value < MinValue || value > MaxValue {
+		return scoreArgs{}, ErrInvalidValue
+	}
+
+	var weight *float64
+
+	if rawWeight := mcp.ParseArgument(request, toolArgWeight, nil); rawWeight != nil {
+		w, ok := rawWeight.(float64)
+		if !ok || w < MinWeight || w > MaxWeight {
+			return scoreArgs{}, ErrInvalidWeight
+		}
+
+		weight = &w
+	}
+
+	metadata, err := tools.ParseObject(request, toolArgMetadata)
+	if err != nil {
+		return scoreArgs{}, err
+	}
+
+	return scoreArgs{
+		value:    int(value),
+		weight:   weight,
+		metadata: metadata,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package feedback_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/feedback"
+)
+
+// scoreTests are the score arguments shared by the create and update tools. A nil wantBody means the arguments are
+// rejected before Portkey is called.
+var scoreTests = []struct { //nolint:gochecknoglobals
+	name     string
+	args     map[string]any
+	wantBody map[string]any
+}{
+	{
+		name:     "lowest value",
+		args:     map[string]any{"value": float64(feedback.MinValue)},
+		wantBody: map[string]any{"value": float64(feedback.MinValue)},
+	},
+	{
+		name:     "highest value",
+		args:     map[string]any{"value": float64(feedback.MaxValue)},
+		wantBody: map[string]any{"value": float64(feedback.MaxValue)},
+	},
+	{
+		name:     "missing value",
+		args:     map[string]any{},
+		wantBody: nil,
+	},
+	{
+		name:     "value below range",
+		args:     map[string]any{"value": float64(feedback.MinValue - 1)},
+		wantBody: nil,
+	},
+	{
+		name:     "value above range",
+		args:     map[string]any{"value": float64(feedback.MaxValue + 1)},
+		wantBody: nil,
+	},
+	{
+		name:     "fractional value",
+		args:     map[string]any{"value": 1.5},
+		wantBody: nil,
+	},
+	{
+		name:     "non-numeric value",
+		args:     map[string]any{"value": "5"},
+		wantBody: nil,
+	},
+	{
+		name:     "lowest weight",
+		args:     map[string]any{"value": float64(1), "weight": feedback.MinWeight},
+		wantBody: map[string]any{"value": float64(1), "weight": feedback.MinWeight},
+	},
+	{
+		name:     "highest weight",
+		args:     map[string]any{"value": float64(1), "weight": feedback.MaxWeight},
+		wantBody: map[string]any{"value": float64(1), "weight": feedback.MaxWeight},
+	},
+	{
+		name:     "weight below range",
+		args:     map[string]any{"value": float64(1), "weight": -0.1},
+		wantBody: nil,
+	},
+	{
+		name:     "weight above range",
+		args:     map[string]any{"value": float64(1), "weight": 1.1},
+		wantBody: nil,
+	},
+	{
+		name:     "non-numeric weight",
+		args:     map[string]any{"value": float64(1), "weight": "0.5"},
+		wantBody: nil,
+	},
+	{
+		name: "metadata",
+		args: map[string]any{"value": float64(1), "metadata": map[string]any{"evaluator": "judge", "round": float64(2)}},
+		wantBody: map[string]any{
+			"value":    float64(1),
+			"metadata": map[string]any{"evaluator": "judge", "round": float64(2)},
+		},
+	},
+	{
+		name:     "string metadata",
+		args:     map[string]any{"value": float64(1), "metadata": "judge"},
+		wantBody: nil,
+	},
+	{
+		name:     "array metadata",
+		args:     map[string]any{"value": float64(1), "metadata": []any{"judge"}},
+		wantBody: nil,
+	},
+}
+
+// fakeFeedback records the method, path and body of the last request it served.
+type fakeFeedback struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+func (f *fakeFeedback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+
+	f.method = r.Method
+	f.path = r.URL.Path
+	f.body = nil
+	_ = json.Unmarshal(data, &f.body)
+
+	_ = json.NewEncoder(w).Encode(feedback.Response{Status: "success", Message: "ok", FeedbackIDs: []string{"fb_1"}})
+}
+
+func callTool(
+	t *testing.T,
+	newTool func(config.Portkey, config.BaseTool) tools.Tuple,
+	args map[string]any,
+) (*fakeFeedback, *mcp.CallToolResult) {
+	t.Helper()
+
+	fake := &fakeFeedback{} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	tool := newTool(portkeyConfig(srv.URL), config.BaseTool{Description: "", Enabled: true})
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+
+	result, err := tool.Handler(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	return fake, result
+}
+
+func portkeyConfig(baseURL string) config.Portkey {
+	return config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: baseURL} //nolint:exhaustruct
+}
+
+func TestCreateToolArguments(t *testing.T) {
+	t.Parallel()
+
+	testScoreArguments(t, feedback.NewCreateTool, "trace_id", "trace-1", true, http.MethodPost, "/feedback")
+}
+
+func TestUpdateToolArguments(t *testing.T) {
+	t.Parallel()
+
+	testScoreArguments(t, feedback.NewUpdateTool, "feedback_id", "fb_1", false, http.MethodPut, "/feedback/fb_1")
+}
+
+// testScoreArguments runs scoreTests against a tool that identifies what it scores by idArg, which is sent in the
+// request body if idInBody is set, and in the path otherwise. It also checks that idArg is required.
+func testScoreArguments(
+	t *testing.T,
+	newTool func(config.Portkey, config.BaseTool) tools.Tuple,
+	idArg, id string,
+	idInBody bool,
+	wantMethod, wantPath string,
+) {
+	t.Helper()
+
+	for _, tt := range scoreTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := map[string]any{idArg: id}
+			for name, value := range tt.args {
+				args[name] = value
+			}
+
+			fake, result := callTool(t, newTool, args)
+
+			if tt.wantBody == nil {
+				assertInvalidInput(t, fake, result)
+
+				return
+			}
+
+			if result.IsError {
+				t.Fatalf("unexpected error result: %v", tools.ResultError(result))
+			}
+
+			if fake.method != wantMethod || fake.path != wantPath {
+				t.Errorf("request = %s %s, want %s %s", fake.method, fake.path, wantMethod, wantPath)
+			}
+
+			wantBody := map[string]any{}
+			for name, value := range tt.wantBody {
+				wantBody[name] = value
+			}
+
+			if idInBody {
+				wantBody[idArg] = id
+			}
+
+			assertBody(t, fake.body, wantBody)
+		})
+	}
+
+	t.Run("missing "+idArg, func(t *testing.T) {
+		t.Parallel()
+
+		fake, result := callTool(t, newTool, map[string]any{"value": float64(1)})
+		assertInvalidInput(t, fake, result)
+	})
+}
+
+func assertInvalidInput(t *testing.T, fake *fakeFeedback, result *mcp.CallToolResult) {
+	t.Helper()
+
+	if !result.IsError || fake.method != "" {
+		t.Errorf("expected invalid input without calling portkey, got error = %v, request method = %q",
+			result.IsError, fake.method)
+	}
+}
+
+func assertBody(t *testing.T, got, want map[string]any) {
+	t.Helper()
+
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("request body = %s, want %s", gotJSON, wantJSON)
+	}
+}
@@ -0,0 +1,22 @@
+package feedback
+
+// CreateRequest represents the request body for the Portkey Create Feedback API.
+type CreateRequest struct {
+	// Required arguments
+	TraceID string `json:"trace_id"`
+	Value   int    `json:"value"`
+
+	// Optional arguments
+	Weight   *float64       `json:"weight,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// UpdateRequest represents the request body for the Portkey Update Feedback API.
+type UpdateRequest struct {
+	// Required arguments
+	Value int `json:"value"`
+
+	// Optional arguments
+	Weight   *float64       `json:"weight,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
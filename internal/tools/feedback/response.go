@@ -0,0 +1,8 @@
+package feedback
+
+// Response represents the full response structure from the Portkey Create and Update Feedback APIs.
+type Response struct {
+	Status      string   `json:"status"`
+	Message     string   `json:"message"`
+	FeedbackIDs []string `json:"feedback_ids,omitempty"`
+}
@@ -0,0 +1,95 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	createToolName = "feedback_create"
+
+	toolArgTraceID = "trace_id"
+)
+
+var ErrTraceIDRequired = errors.New("trace_id is required")
+
+func NewCreateTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Attach a feedback score to a Portkey trace. Use this to record evaluations of generated " +
+		"outputs, so they show up alongside the trace in Portkey's logs and analytics."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Feedback"),
+		mcp.WithOutputSchema[Response](),
+		mcp.WithString(toolArgTraceID,
+			mcp.Required(),
+			mcp.Description("The trace ID of the request(s) the feedback is about."),
+		),
+	}
+
+	createTool := mcp.NewTool(createToolName, append(opts, withScoreArguments()...)...)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: createHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// createHandler calls the Portkey Create Feedback API and returns the result.
+func createHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    portkey.BaseURL + "/feedback",
+			Body:   req,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getCreateToolArguments(request mcp.CallToolRequest) (CreateRequest, error) {
+	traceID := mcp.ParseString(request, toolArgTraceID, "")
+	if traceID == "" {
+		return CreateRequest{}, ErrTraceIDRequired
+	}
+
+	score, err := getScoreArguments(request)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	return CreateRequest{
+		TraceID:  traceID,
+		Value:    score.value,
+		Weight:   score.weight,
+		Metadata: score.metadata,
+	}, nil
+}
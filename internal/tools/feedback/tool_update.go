@@ -0,0 +1,104 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	updateToolName = "feedback_update"
+
+	toolArgFeedbackID = "feedback_id"
+)
+
+var ErrFeedbackIDRequired = errors.New("feedback_id is required")
+
+type updateToolArgs struct {
+	feedbackID string
+	request    UpdateRequest
+}
+
+func NewUpdateTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Update the score, weight or metadata of feedback previously attached to a Portkey trace with " +
+		"the feedback_create tool."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.UpdateAnnotation("Update Feedback"),
+		mcp.WithOutputSchema[Response](),
+		mcp.WithString(toolArgFeedbackID,
+			mcp.Required(),
+			mcp.Description("The ID of the feedback to update, as returned by the feedback_create tool."),
+		),
+	}
+
+	updateTool := mcp.NewTool(updateToolName, append(opts, withScoreArguments()...)...)
+
+	return tools.Tuple{
+		Tool:    &updateTool,
+		Handler: updateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// updateHandler calls the Portkey Update Feedback API and returns the result.
+func updateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    fmt.Sprintf("%s/feedback/%s", portkey.BaseURL, url.PathEscape(args.feedbackID)),
+			Body:   args.request,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getUpdateToolArguments(request mcp.CallToolRequest) (updateToolArgs, error) {
+	feedbackID := mcp.ParseString(request, toolArgFeedbackID, "")
+	if feedbackID == "" {
+		return updateToolArgs{}, ErrFeedbackIDRequired
+	}
+
+	score, err := getScoreArguments(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	return updateToolArgs{
+		feedbackID: feedbackID,
+		request: UpdateRequest{
+			Value:    score.value,
+			Weight:   score.weight,
+			Metadata: score.metadata,
+		},
+	}, nil
+}
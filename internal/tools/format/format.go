@@ -0,0 +1,92 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+const (
+	toolArgOutputFormat = "output_format"
+
+	errTextInternalError = "internal error while processing request"
+)
+
+// Markdowner is implemented by tool results that can be summarized for humans.
+type Markdowner interface {
+	Markdown() string
+}
+
+// Options control how a tool result is formatted.
+type Options struct {
+	Format                config.ResultFormat
+	PreserveUnknownFields bool
+}
+
+// WithOutputFormatArgument adds the argument that selects the format of a tool's result.
+func WithOutputFormatArgument(cfg config.Results) mcp.ToolOption {
+	return mcp.WithString(toolArgOutputFormat,
+		mcp.Description(fmt.Sprintf("Optional. How to return the result: 'json' for the JSON response, 'markdown' "+
+			"for a human-readable summary, or 'both'. Defaults to '%s'.", cfg.DefaultFormat)),
+		mcp.Enum(config.ResultFormats()...),
+	)
+}
+
+// GetOptions returns the formatting options of a tool call.
+func GetOptions(request mcp.CallToolRequest, cfg config.Results) (Options, error) {
+	format := cfg.DefaultFormat
+
+	if value := mcp.ParseString(request, toolArgOutputFormat, ""); value != "" {
+		if !slices.Contains(config.ResultFormats(), value) {
+			return Options{}, fmt.Errorf("%w: %q", config.ErrInvalidResultFormat, value)
+		}
+
+		format = config.ResultFormat(value)
+	}
+
+	return Options{
+		Format:                format,
+		PreserveUnknownFields: cfg.PreserveUnknownFields,
+	}, nil
+}
+
+// NewResult returns a typed Portkey response to the agent. The response is always returned as structured content, and
+// the content blocks hold its JSON, its markdown summary, or both. When unknown fields are preserved, they are merged
+// back into the JSON from the raw response body.
+func NewResult(lgr *slog.Logger, opts Options, resp Markdowner, raw []byte) *mcp.CallToolResult {
+	var content []mcp.Content
+
+	if opts.Format != config.ResultFormatMarkdown {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			lgr.Error("failed to marshal tool result", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError)
+		}
+
+		if opts.PreserveUnknownFields {
+			data, err = MergeUnknownFields(data, raw)
+			if err != nil {
+				lgr.Error("failed to preserve unknown fields of tool result", "error", err)
+
+				return mcp.NewToolResultError(errTextInternalError)
+			}
+		}
+
+		content = append(content, mcp.NewTextContent(string(data)))
+	}
+
+	if opts.Format != config.ResultFormatJSON {
+		content = append(content, mcp.NewTextContent(resp.Markdown()))
+	}
+
+	return &mcp.CallToolResult{ //nolint:exhaustruct
+		Content:           content,
+		StructuredContent: resp,
+	}
+}
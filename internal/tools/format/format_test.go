@@ -0,0 +1,81 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/format"
+)
+
+func TestMergeUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		typed string
+		raw   string
+		want  string
+	}{
+		{
+			name:  "no unknown fields",
+			typed: `{"b":1,"a":2}`,
+			raw:   `{"a":2,"b":1}`,
+			want:  `{"b":1,"a":2}`,
+		},
+		{
+			name:  "unknown fields follow known ones alphabetically",
+			typed: `{"id":"1"}`,
+			raw:   `{"zeta":true,"id":"1","alpha":null}`,
+			want:  `{"id":"1","alpha":null,"zeta":true}`,
+		},
+		{
+			name:  "nested objects and arrays",
+			typed: `{"data":[{"id":"1"},{"id":"2"}],"meta":{"total":2}}`,
+			raw:   `{"data":[{"id":"1","x":1},{"id":"2"}],"meta":{"total":2,"page":1}}`,
+			want:  `{"data":[{"id":"1","x":1},{"id":"2"}],"meta":{"total":2,"page":1}}`,
+		},
+		{
+			name:  "arrays of different lengths are left alone",
+			typed: `{"data":[{"id":"1"}]}`,
+			raw:   `{"data":[{"id":"1","x":1},{"id":"2"}]}`,
+			want:  `{"data":[{"id":"1"}]}`,
+		},
+		{
+			name:  "typed values win",
+			typed: `{"status":"active"}`,
+			raw:   `{"status":{"unexpected":"shape"}}`,
+			want:  `{"status":"active"}`,
+		},
+		{
+			name:  "empty typed object",
+			typed: `{}`,
+			raw:   `{"b":1,"a":2}`,
+			want:  `{"a":2,"b":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := format.MergeUnknownFields([]byte(tt.typed), []byte(tt.raw))
+			if err != nil {
+				t.Fatalf("MergeUnknownFields() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("MergeUnknownFields() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	got := format.Table([]string{"Name", "Slug"}, [][]string{{"A | B", "line\nbreak"}})
+	want := "| Name | Slug |\n| --- | --- |\n| A \\| B | line break |\n"
+
+	if got != want {
+		t.Errorf("Table() = %q, want %q", got, want)
+	}
+}
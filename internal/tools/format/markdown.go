@@ -0,0 +1,44 @@
+package format
+
+import "strings"
+
+// Table renders a markdown table.
+func Table(headers []string, rows [][]string) string {
+	var sb strings.Builder
+
+	writeRow(&sb, headers)
+
+	sb.WriteString("|")
+
+	for range headers {
+		sb.WriteString(" --- |")
+	}
+
+	sb.WriteString("\n")
+
+	for _, row := range rows {
+		writeRow(&sb, row)
+	}
+
+	return sb.String()
+}
+
+func writeRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+
+	for _, cell := range cells {
+		sb.WriteString(" ")
+		sb.WriteString(escapeCell(cell))
+		sb.WriteString(" |")
+	}
+
+	sb.WriteString("\n")
+}
+
+// escapeCell keeps a value from breaking out of its table cell.
+func escapeCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", " ")
+
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,146 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MergeUnknownFields adds the fields of raw that are missing from typed, at any depth. Fields keep the order of typed,
+// and added fields follow them in alphabetical order, so the output is stable. Arrays are merged element by element
+// when they have the same length.
+func MergeUnknownFields(typed, raw []byte) ([]byte, error) {
+	typed = bytes.TrimSpace(typed)
+	raw = bytes.TrimSpace(raw)
+
+	switch {
+	case isJSON(typed, '{') && isJSON(raw, '{'):
+		return mergeObjects(typed, raw)
+	case isJSON(typed, '[') && isJSON(raw, '['):
+		return mergeArrays(typed, raw)
+	default:
+		return typed, nil
+	}
+}
+
+func isJSON(data []byte, opening byte) bool {
+	return len(data) > 0 && data[0] == opening
+}
+
+func mergeObjects(typed, raw []byte) ([]byte, error) {
+	keys, values, err := orderedFields(typed)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawFields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rawFields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw object: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range keys {
+		value := values[i]
+
+		if rawValue, ok := rawFields[key]; ok {
+			value, err = MergeUnknownFields(value, rawValue)
+			if err != nil {
+				return nil, err
+			}
+
+			delete(rawFields, key)
+		}
+
+		writeField(&buf, i > 0, key, value)
+	}
+
+	for i, key := range slices.Sorted(maps.Keys(rawFields)) {
+		writeField(&buf, len(keys) > 0 || i > 0, key, rawFields[key])
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func mergeArrays(typed, raw []byte) ([]byte, error) {
+	var typedItems, rawItems []json.RawMessage
+
+	if err := json.Unmarshal(typed, &typedItems); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal typed array: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &rawItems); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw array: %w", err)
+	}
+
+	if len(typedItems) != len(rawItems) {
+		return typed, nil
+	}
+
+	for i := range typedItems {
+		merged, err := MergeUnknownFields(typedItems[i], rawItems[i])
+		if err != nil {
+			return nil, err
+		}
+
+		typedItems[i] = merged
+	}
+
+	data, err := json.Marshal(typedItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged array: %w", err)
+	}
+
+	return data, nil
+}
+
+// orderedFields returns the keys and values of a JSON object, in order.
+func orderedFields(obj []byte) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(obj))
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	var (
+		keys   []string
+		values []json.RawMessage
+	)
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read object key: %w", err)
+		}
+
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to read object value: %w", err)
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return keys, values, nil
+}
+
+func writeField(buf *bytes.Buffer, comma bool, key string, value []byte) {
+	if comma {
+		buf.WriteByte(',')
+	}
+
+	name, _ := json.Marshal(key)
+
+	buf.Write(name)
+	buf.WriteByte(':')
+	buf.Write(value)
+}
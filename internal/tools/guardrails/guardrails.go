@@ -0,0 +1,159 @@
+package guardrails
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/feedback"
+)
+
+const (
+	// Tool arguments shared by several guardrail tools.
+	toolArgGuardrailID    = "guardrail_id"
+	toolArgName           = "name"
+	toolArgChecks         = "checks"
+	toolArgActions        = "actions"
+	toolArgWorkspaceID    = "workspace_id"
+	toolArgOrganisationID = "organisation_id"
+)
+
+var (
+	ErrGuardrailIDRequired  = errors.New("guardrail_id is required")
+	ErrCheckIDRequired      = errors.New("every check must have an id")
+	ErrInvalidFeedbackValue = fmt.Errorf("feedback value must be an integer between %d and %d",
+		feedback.MinValue, feedback.MaxValue)
+	ErrInvalidFeedbackWeight = fmt.Errorf("feedback weight must be a number between %g and %g",
+		feedback.MinWeight, feedback.MaxWeight)
+)
+
+//nolint:gochecknoglobals
+var (
+	checkSchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{
+				"type": "string",
+				"description": "The kind of check, e.g. 'default.regexMatch', 'default.jsonSchema', 'portkey.pii' or " +
+					"'default.webhook'.",
+			},
+			"name":       map[string]any{"type": "string"},
+			"is_enabled": map[string]any{"type": "boolean"},
+			"parameters": map[string]any{
+				"type":        "object",
+				"description": "Check-specific parameters, e.g. {\"rule\": \"\\\\d{3}-\\\\d{2}-\\\\d{4}\"} for a regex check.",
+			},
+		},
+		"required": []string{"id"},
+	}
+
+	feedbackSchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"value":    map[string]any{"type": "integer", "minimum": feedback.MinValue, "maximum": feedback.MaxValue},
+			"weight":   map[string]any{"type": "number", "minimum": feedback.MinWeight, "maximum": feedback.MaxWeight},
+			"metadata": map[string]any{"type": "object"},
+		},
+		"required": []string{"value", "weight"},
+	}
+
+	actionsProperties = map[string]any{
+		"async": map[string]any{
+			"type":        "boolean",
+			"description": "Run the checks without blocking the request, so results are only logged.",
+		},
+		"deny": map[string]any{
+			"type":        "boolean",
+			"description": "Block the request when the checks fail.",
+		},
+		"on_success": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"feedback": feedbackSchema},
+		},
+		"on_fail": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"feedback": feedbackSchema},
+		},
+	}
+)
+
+func withGuardrailID() mcp.ToolOption {
+	return mcp.WithString(toolArgGuardrailID,
+		mcp.Required(),
+		mcp.Description("The ID or slug of the guardrail."),
+	)
+}
+
+func withChecks(opts ...mcp.PropertyOption) mcp.ToolOption {
+	opts = append(opts, mcp.Items(checkSchema))
+
+	return mcp.WithArray(toolArgChecks, opts...)
+}
+
+func withActions(opts ...mcp.PropertyOption) mcp.ToolOption {
+	opts = append(opts, mcp.Properties(actionsProperties))
+
+	return mcp.WithObject(toolArgActions, opts...)
+}
+
+func getGuardrailID(request mcp.CallToolRequest) (string, error) {
+	guardrailID := mcp.ParseString(request, toolArgGuardrailID, "")
+	if guardrailID == "" {
+		return "", ErrGuardrailIDRequired
+	}
+
+	return guardrailID, nil
+}
+
+func getChecks(request mcp.CallToolRequest) ([]Check, error) {
+	var checks []Check
+	if _, err := tools.DecodeArgument(request, toolArgChecks, &checks); err != nil {
+		return nil, err
+	}
+
+	for _, check := range checks {
+		if check.ID == "" {
+			return nil, ErrCheckIDRequired
+		}
+	}
+
+	return checks, nil
+}
+
+func getActions(request mcp.CallToolRequest) (*Actions, error) {
+	var actions Actions
+
+	present, err := tools.DecodeArgument(request, toolArgActions, &actions)
+	if err != nil || !present {
+		return nil, err
+	}
+
+	for _, outcome := range []*Outcome{actions.OnSuccess, actions.OnFail} {
+		if outcome == nil || outcome.Feedback == nil {
+			continue
+		}
+
+		if outcome.Feedback.Value < feedback.MinValue || outcome.Feedback.Value > feedback.MaxValue {
+			return nil, ErrInvalidFeedbackValue
+		}
+
+		if outcome.Feedback.Weight < feedback.MinWeight || outcome.Feedback.Weight > feedback.MaxWeight {
+			return nil, ErrInvalidFeedbackWeight
+		}
+	}
+
+	return &actions, nil
+}
+
+// createURL returns the URL of the guardrails collection, or of a single guardrail when an ID is provided.
+func createURL(portkey config.Portkey, guardrailID string) string {
+	if guardrailID == "" {
+		return portkey.BaseURL + "/guardrails"
+	}
+
+	return fmt.Sprintf("%s/guardrails/%s", portkey.BaseURL, url.PathEscape(guardrailID))
+}
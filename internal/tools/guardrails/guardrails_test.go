@@ -0,0 +1,333 @@
+package guardrails_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/guardrails"
+)
+
+// fakeGuardrails records the method, path and body of the last request it served.
+type fakeGuardrails struct {
+	method string
+	path   string
+	body   string
+}
+
+func (f *fakeGuardrails) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+
+	f.method = r.Method
+	f.path = r.URL.Path
+	f.body = string(data)
+
+	_ = json.NewEncoder(w).Encode(guardrails.MutationResponse{}) //nolint:exhaustruct
+}
+
+func callTool(
+	t *testing.T,
+	newTool func(config.Portkey, config.WriteTool) tools.Tuple,
+	args map[string]any,
+) (*fakeGuardrails, *mcp.CallToolResult) {
+	t.Helper()
+
+	fake := &fakeGuardrails{} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	tool := newTool(
+		config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: srv.URL}, //nolint:exhaustruct
+		config.WriteTool{Description: "", Enabled: true},
+	)
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+
+	result, err := tool.Handler(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	return fake, result
+}
+
+// assertResult checks that the tool was rejected with wantErr before Portkey was called, or, without wantErr, that it
+// sent wantBody.
+func assertResult(t *testing.T, fake *fakeGuardrails, result *mcp.CallToolResult, wantErr error, wantBody string) {
+	t.Helper()
+
+	if wantErr != nil {
+		if !result.IsError || !strings.Contains(tools.ResultError(result).Error(), wantErr.Error()) {
+			t.Errorf("expected error %q, got error = %v, content = %v", wantErr, result.IsError, result.Content)
+		}
+
+		if fake.method != "" {
+			t.Errorf("expected portkey not to be called, got %s %s", fake.method, fake.path)
+		}
+
+		return
+	}
+
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", tools.ResultError(result))
+	}
+
+	if strings.TrimSpace(fake.body) != wantBody {
+		t.Errorf("request body = %s, want %s", fake.body, wantBody)
+	}
+}
+
+func TestCreateToolArguments(t *testing.T) {
+	t.Parallel()
+
+	regexCheck := map[string]any{"id": "default.regexMatch", "parameters": map[string]any{"rule": "\\d+"}}
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantErr  error
+		wantBody string
+	}{
+		{
+			name:    "valid",
+			args:    map[string]any{"checks": []any{regexCheck}, "workspace_id": "ws_1"},
+			wantErr: nil,
+			wantBody: `{"name":"pii","checks":[{"id":"default.regexMatch","parameters":{"rule":"\\d+"}}],` +
+				`"workspace_id":"ws_1"}`,
+		},
+		{
+			name: "valid with actions",
+			args: map[string]any{
+				"checks":          []any{map[string]any{"id": "portkey.pii", "is_enabled": true}},
+				"organisation_id": "org_1",
+				"actions": map[string]any{
+					"deny":    true,
+					"on_fail": map[string]any{"feedback": map[string]any{"value": float64(-10), "weight": float64(1)}},
+				},
+			},
+			wantErr: nil,
+			wantBody: `{"name":"pii","checks":[{"id":"portkey.pii","is_enabled":true}],` +
+				`"actions":{"async":false,"deny":true,"on_fail":{"feedback":{"value":-10,"weight":1}}},` +
+				`"organisation_id":"org_1"}`,
+		},
+		{
+			name:     "missing name",
+			args:     map[string]any{"name": "", "checks": []any{regexCheck}, "workspace_id": "ws_1"},
+			wantErr:  guardrails.ErrNameRequired,
+			wantBody: "",
+		},
+		{
+			name:     "missing checks",
+			args:     map[string]any{"workspace_id": "ws_1"},
+			wantErr:  guardrails.ErrChecksRequired,
+			wantBody: "",
+		},
+		{
+			name:     "empty checks",
+			args:     map[string]any{"checks": []any{}, "workspace_id": "ws_1"},
+			wantErr:  guardrails.ErrChecksRequired,
+			wantBody: "",
+		},
+		{
+			name:     "check without id",
+			args:     map[string]any{"checks": []any{map[string]any{"name": "regex"}}, "workspace_id": "ws_1"},
+			wantErr:  guardrails.ErrCheckIDRequired,
+			wantBody: "",
+		},
+		{
+			name: "check with unknown field",
+			args: map[string]any{
+				"checks":       []any{map[string]any{"id": "portkey.pii", "enabled": true}},
+				"workspace_id": "ws_1",
+			},
+			wantErr:  tools.ErrInvalidArgumentShape,
+			wantBody: "",
+		},
+		{
+			name:     "checks not an array",
+			args:     map[string]any{"checks": regexCheck, "workspace_id": "ws_1"},
+			wantErr:  tools.ErrInvalidArgumentShape,
+			wantBody: "",
+		},
+		{
+			name:     "missing scope",
+			args:     map[string]any{"checks": []any{regexCheck}},
+			wantErr:  guardrails.ErrScopeRequired,
+			wantBody: "",
+		},
+		{
+			name: "actions with unknown field",
+			args: map[string]any{
+				"checks":       []any{regexCheck},
+				"workspace_id": "ws_1",
+				"actions":      map[string]any{"block": true},
+			},
+			wantErr:  tools.ErrInvalidArgumentShape,
+			wantBody: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := map[string]any{"name": "pii"}
+			for name, value := range tt.args {
+				args[name] = value
+			}
+
+			fake, result := callTool(t, guardrails.NewCreateTool, args)
+			assertResult(t, fake, result, tt.wantErr, tt.wantBody)
+		})
+	}
+}
+
+func TestUpdateToolArguments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantErr  error
+		wantBody string
+	}{
+		{
+			name:     "name only",
+			args:     map[string]any{"name": "pii"},
+			wantErr:  nil,
+			wantBody: `{"name":"pii"}`,
+		},
+		{
+			name:     "checks only",
+			args:     map[string]any{"checks": []any{map[string]any{"id": "portkey.pii"}}},
+			wantErr:  nil,
+			wantBody: `{"checks":[{"id":"portkey.pii"}]}`,
+		},
+		{
+			name:     "actions only",
+			args:     map[string]any{"actions": map[string]any{"async": true}},
+			wantErr:  nil,
+			wantBody: `{"actions":{"async":true,"deny":false}}`,
+		},
+		{
+			name:     "missing guardrail_id",
+			args:     map[string]any{"guardrail_id": "", "name": "pii"},
+			wantErr:  guardrails.ErrGuardrailIDRequired,
+			wantBody: "",
+		},
+		{
+			name:     "nothing to update",
+			args:     map[string]any{},
+			wantErr:  guardrails.ErrNothingToUpdate,
+			wantBody: "",
+		},
+		{
+			name:     "check without id",
+			args:     map[string]any{"checks": []any{map[string]any{"is_enabled": false}}},
+			wantErr:  guardrails.ErrCheckIDRequired,
+			wantBody: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := map[string]any{"guardrail_id": "gr_1"}
+			for name, value := range tt.args {
+				args[name] = value
+			}
+
+			fake, result := callTool(t, guardrails.NewUpdateTool, args)
+			assertResult(t, fake, result, tt.wantErr, tt.wantBody)
+
+			if tt.wantErr == nil && (fake.method != http.MethodPut || fake.path != "/guardrails/gr_1") {
+				t.Errorf("request = %s %s, want PUT /guardrails/gr_1", fake.method, fake.path)
+			}
+		})
+	}
+}
+
+// TestActionFeedbackBounds makes sure that the feedback recorded by a guardrail's actions is held to the same bounds
+// as the feedback tools, for both outcomes.
+func TestActionFeedbackBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		feedback map[string]any
+		wantErr  error
+	}{
+		{
+			name:     "lowest value and weight",
+			feedback: map[string]any{"value": float64(-10), "weight": float64(0)},
+			wantErr:  nil,
+		},
+		{
+			name:     "highest value and weight",
+			feedback: map[string]any{"value": float64(10), "weight": float64(1)},
+			wantErr:  nil,
+		},
+		{
+			name:     "value below range",
+			feedback: map[string]any{"value": float64(-11), "weight": float64(1)},
+			wantErr:  guardrails.ErrInvalidFeedbackValue,
+		},
+		{
+			name:     "value above range",
+			feedback: map[string]any{"value": float64(11), "weight": float64(1)},
+			wantErr:  guardrails.ErrInvalidFeedbackValue,
+		},
+		{
+			name:     "fractional value",
+			feedback: map[string]any{"value": 1.5, "weight": float64(1)},
+			wantErr:  tools.ErrInvalidArgumentShape,
+		},
+		{
+			name:     "weight below range",
+			feedback: map[string]any{"value": float64(1), "weight": -0.1},
+			wantErr:  guardrails.ErrInvalidFeedbackWeight,
+		},
+		{
+			name:     "weight above range",
+			feedback: map[string]any{"value": float64(1), "weight": 1.1},
+			wantErr:  guardrails.ErrInvalidFeedbackWeight,
+		},
+		{
+			name:     "non-numeric weight",
+			feedback: map[string]any{"value": float64(1), "weight": "0.5"},
+			wantErr:  tools.ErrInvalidArgumentShape,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, outcome := range []string{"on_success", "on_fail"} {
+			t.Run(tt.name+" "+outcome, func(t *testing.T) {
+				t.Parallel()
+
+				fake, result := callTool(t, guardrails.NewUpdateTool, map[string]any{
+					"guardrail_id": "gr_1",
+					"actions":      map[string]any{outcome: map[string]any{"feedback": tt.feedback}},
+				})
+
+				if tt.wantErr == nil {
+					if result.IsError || fake.method == "" {
+						t.Errorf("expected portkey to be called, got error = %v, content = %v", result.IsError, result.Content)
+					}
+
+					return
+				}
+
+				assertResult(t, fake, result, tt.wantErr, "")
+			})
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package guardrails
+
+// CreateRequest represents the request body for the Portkey Create Guardrail API.
+type CreateRequest struct {
+	// Required arguments
+	Name   string  `json:"name"`
+	Checks []Check `json:"checks"`
+
+	// Optional arguments
+	Actions        *Actions `json:"actions,omitempty"`
+	WorkspaceID    string   `json:"workspace_id,omitempty"`
+	OrganisationID string   `json:"organisation_id,omitempty"`
+}
+
+// UpdateRequest represents the request body for the Portkey Update Guardrail API.
+type UpdateRequest struct {
+	// Optional arguments
+	Name    string   `json:"name,omitempty"`
+	Checks  []Check  `json:"checks,omitempty"`
+	Actions *Actions `json:"actions,omitempty"`
+}
+
+// Check is a single guardrail check, e.g. a PII or regex check.
+type Check struct {
+	// ID identifies the kind of check, e.g. "default.regexMatch" or "portkey.pii".
+	ID         string         `json:"id"`
+	Name       string         `json:"name,omitempty"`
+	IsEnabled  *bool          `json:"is_enabled,omitempty"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// Actions describes what happens when a guardrail's checks pass or fail.
+type Actions struct {
+	// Async runs the checks without blocking the request, so results are only logged.
+	Async bool `json:"async"`
+
+	// Deny blocks the request when the checks fail.
+	Deny bool `json:"deny"`
+
+	OnSuccess *Outcome `json:"on_success,omitempty"`
+	OnFail    *Outcome `json:"on_fail,omitempty"`
+}
+
+// Outcome holds the actions taken for a passing or failing guardrail.
+type Outcome struct {
+	Feedback *Feedback `json:"feedback,omitempty"`
+}
+
+// Feedback is recorded against the request's trace when a guardrail passes or fails.
+type Feedback struct {
+	Value    int            `json:"value"`
+	Weight   float64        `json:"weight"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
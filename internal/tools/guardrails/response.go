@@ -0,0 +1,42 @@
+package guardrails
+
+import "time"
+
+// ListResponse represents the full response structure from the Portkey List Guardrails API.
+type ListResponse struct {
+	Data  []Summary `json:"data"`
+	Total int       `json:"total"`
+}
+
+// Summary represents a single guardrail entry in the List Guardrails API response.
+type Summary struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"`
+	WorkspaceID    string    `json:"workspace_id,omitempty"`
+	OrganisationID string    `json:"organisation_id,omitempty"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastUpdatedAt  time.Time `json:"last_updated_at"`
+}
+
+// Guardrail represents the response from the Portkey Retrieve Guardrail API.
+type Guardrail struct {
+	Summary
+
+	Checks  []Check `json:"checks"`
+	Actions Actions `json:"actions"`
+}
+
+// MutationResponse represents the response from the Portkey Create and Update Guardrail APIs.
+type MutationResponse struct {
+	ID        string `json:"id"`
+	Slug      string `json:"slug"`
+	VersionID string `json:"version_id"`
+}
+
+// DeleteResponse is returned by the guardrail_delete tool once a guardrail has been deleted.
+type DeleteResponse struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
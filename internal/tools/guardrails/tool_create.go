@@ -0,0 +1,125 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const createToolName = "guardrail_create"
+
+var (
+	ErrNameRequired   = errors.New("name is required")
+	ErrChecksRequired = errors.New("at least one check is required")
+	ErrScopeRequired  = errors.New("one of workspace_id or organisation_id is required")
+)
+
+func NewCreateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Create a new guardrail in your Portkey account, with a list of checks and the actions to take " +
+		"when they pass or fail."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	createTool := mcp.NewTool(
+		createToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Guardrail"),
+		mcp.WithOutputSchema[MutationResponse](),
+		mcp.WithString(toolArgName,
+			mcp.Required(),
+			mcp.Description("Name of the guardrail to create."),
+		),
+		withChecks(
+			mcp.Required(),
+			mcp.Description("The checks the guardrail runs."),
+		),
+		withActions(
+			mcp.Description("Optional. What to do when the checks pass or fail. By default, failures are only "+
+				"logged."),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("The workspace to create the guardrail in. Either this or organisation_id is required."),
+		),
+		mcp.WithString(toolArgOrganisationID,
+			mcp.Description("The organisation to create the guardrail in. Either this or workspace_id is required."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: createHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// createHandler calls the Portkey Create Guardrail API and returns the result.
+func createHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, ""),
+			Body:   req,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getCreateToolArguments(request mcp.CallToolRequest) (CreateRequest, error) {
+	name := mcp.ParseString(request, toolArgName, "")
+	if name == "" {
+		return CreateRequest{}, ErrNameRequired
+	}
+
+	checks, err := getChecks(request)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	if len(checks) == 0 {
+		return CreateRequest{}, ErrChecksRequired
+	}
+
+	actions, err := getActions(request)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	workspaceID := mcp.ParseString(request, toolArgWorkspaceID, "")
+	organisationID := mcp.ParseString(request, toolArgOrganisationID, "")
+
+	if workspaceID == "" && organisationID == "" {
+		return CreateRequest{}, ErrScopeRequired
+	}
+
+	return CreateRequest{
+		Name:           name,
+		Checks:         checks,
+		Actions:        actions,
+		WorkspaceID:    workspaceID,
+		OrganisationID: organisationID,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package guardrails
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const deleteToolName = "guardrail_delete"
+
+func NewDeleteTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Delete a guardrail from your Portkey account. Configs that reference the guardrail will no " +
+		"longer run its checks."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	deleteTool := mcp.NewTool(
+		deleteToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Delete Guardrail"),
+		mcp.WithOutputSchema[DeleteResponse](),
+		withGuardrailID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &deleteTool,
+		Handler: deleteHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// deleteHandler calls the Portkey Delete Guardrail API and returns the result.
+func deleteHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		guardrailID, err := getGuardrailID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodDelete,
+			URL:    createURL(portkey, guardrailID),
+			Body:   nil,
+		}, nil)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, DeleteResponse{ID: guardrailID, Deleted: true}), nil
+	}
+}
@@ -0,0 +1,65 @@
+package guardrails
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const getToolName = "guardrail_get"
+
+func NewGetTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Retrieve a Portkey guardrail, including its checks and the actions taken when they pass or " +
+		"fail. Use this together with a config's guardrail settings to explain why a request was blocked."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	getTool := mcp.NewTool(
+		getToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Get Guardrail"),
+		mcp.WithOutputSchema[Guardrail](),
+		withGuardrailID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &getTool,
+		Handler: getHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// getHandler calls the Portkey Retrieve Guardrail API and returns the result.
+func getHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		guardrailID, err := getGuardrailID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp Guardrail
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createURL(portkey, guardrailID),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
@@ -0,0 +1,157 @@
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	listToolName = "guardrails_list"
+
+	// Tool arguments.
+	toolArgCurrentPage = "current_page"
+	toolArgPageSize    = "page_size"
+
+	// Portkey API query parameters.
+	apiParamWorkspaceID    = "workspace_id"
+	apiParamOrganisationID = "organisation_id"
+	apiParamCurrentPage    = "current_page"
+	apiParamPageSize       = "page_size"
+)
+
+var (
+	ErrInvalidPageSize    = fmt.Errorf("%s must be a positive integer", toolArgPageSize)
+	ErrInvalidCurrentPage = fmt.Errorf("%s must be a positive integer", toolArgCurrentPage)
+)
+
+type listToolArgs struct {
+	workspaceID    string
+	organisationID string
+	currentPage    *int
+	pageSize       *int
+}
+
+func NewListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the guardrails in your Portkey account. Guardrails run checks (like PII detection, regex " +
+		"matches, JSON schema validation or custom webhooks) on requests and responses, and can deny, log or " +
+		"record feedback on the result. Use guardrail_get to see a guardrail's checks and actions."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	listTool := mcp.NewTool(
+		listToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Guardrails"),
+		mcp.WithOutputSchema[ListResponse](),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Filter guardrails by workspace ID."),
+		),
+		mcp.WithString(toolArgOrganisationID,
+			mcp.Description("Optional. Filter guardrails by organisation ID."),
+		),
+		mcp.WithNumber(toolArgCurrentPage,
+			mcp.Description("Optional. Page number for pagination. Starts at 1."),
+		),
+		mcp.WithNumber(toolArgPageSize,
+			mcp.Description("Optional. Number of results per page."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &listTool,
+		Handler: listHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// listHandler calls the Portkey List Guardrails API and returns the result.
+func listHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getListToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp ListResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createListURL(portkey, args),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getListToolArguments(request mcp.CallToolRequest) (listToolArgs, error) {
+	//nolint:exhaustruct
+	args := listToolArgs{
+		workspaceID:    mcp.ParseString(request, toolArgWorkspaceID, ""),
+		organisationID: mcp.ParseString(request, toolArgOrganisationID, ""),
+	}
+
+	// Handle optional integer arguments.
+	currentPage := mcp.ParseInt(request, toolArgCurrentPage, 0)
+	if currentPage > 0 {
+		args.currentPage = &currentPage
+	} else if currentPage < 0 {
+		return listToolArgs{}, ErrInvalidCurrentPage
+	}
+
+	pageSize := mcp.ParseInt(request, toolArgPageSize, 0)
+	if pageSize > 0 {
+		args.pageSize = &pageSize
+	} else if pageSize < 0 {
+		return listToolArgs{}, ErrInvalidPageSize
+	}
+
+	return args, nil
+}
+
+func createListURL(portkey config.Portkey, args listToolArgs) string {
+	baseURL := createURL(portkey, "")
+
+	// Add query parameters.
+	values := url.Values{}
+	if args.workspaceID != "" {
+		values.Add(apiParamWorkspaceID, args.workspaceID)
+	}
+
+	if args.organisationID != "" {
+		values.Add(apiParamOrganisationID, args.organisationID)
+	}
+
+	if args.currentPage != nil {
+		values.Add(apiParamCurrentPage, strconv.Itoa(*args.currentPage))
+	}
+
+	if args.pageSize != nil {
+		values.Add(apiParamPageSize, strconv.Itoa(*args.pageSize))
+	}
+
+	if len(values) > 0 {
+		return baseURL + "?" + values.Encode()
+	}
+
+	return baseURL
+}
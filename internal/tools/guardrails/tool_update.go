@@ -0,0 +1,114 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const updateToolName = "guardrail_update"
+
+var ErrNothingToUpdate = errors.New("at least one of name, checks or actions is required")
+
+type updateToolArgs struct {
+	guardrailID string
+	request     UpdateRequest
+}
+
+func NewUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Update the name, checks or actions of an existing Portkey guardrail. Provided checks replace the " +
+		"guardrail's existing checks."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	updateTool := mcp.NewTool(
+		updateToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Update Guardrail"),
+		mcp.WithOutputSchema[MutationResponse](),
+		withGuardrailID(),
+		mcp.WithString(toolArgName,
+			mcp.Description("Optional. The new name of the guardrail."),
+		),
+		withChecks(
+			mcp.Description("Optional. The checks the guardrail runs, replacing the existing checks."),
+		),
+		withActions(
+			mcp.Description("Optional. What to do when the checks pass or fail."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &updateTool,
+		Handler: updateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// updateHandler calls the Portkey Update Guardrail API and returns the result.
+func updateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, args.guardrailID),
+			Body:   args.request,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getUpdateToolArguments(request mcp.CallToolRequest) (updateToolArgs, error) {
+	guardrailID, err := getGuardrailID(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	checks, err := getChecks(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	actions, err := getActions(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	name := mcp.ParseString(request, toolArgName, "")
+
+	if name == "" && len(checks) == 0 && actions == nil {
+		return updateToolArgs{}, ErrNothingToUpdate
+	}
+
+	return updateToolArgs{
+		guardrailID: guardrailID,
+		request: UpdateRequest{
+			Name:    name,
+			Checks:  checks,
+			Actions: actions,
+		},
+	}, nil
+}
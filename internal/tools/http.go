@@ -1,16 +1,36 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/apikey"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
 )
 
+const errTextInternalError = "internal error while processing request"
+
+var ErrPortkeyRequestFailed = errors.New("portkey request failed")
+
+// PortkeyRequest describes a single call to the Portkey API made on behalf of a tool.
+type PortkeyRequest struct {
+	Method string
+	URL    string
+
+	// Body is marshaled to JSON and sent as the request body, if non-nil.
+	Body any
+}
+
 func MakePortkeyAPIRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	client := middleware.GetHTTPClient(ctx)
 
@@ -22,6 +42,130 @@ func MakePortkeyAPIRequest(ctx context.Context, req *http.Request) (*http.Respon
 	return resp, nil
 }
 
+// SetAPIKeyHeader sets the Portkey API key that the request is made with, which is the server's or the client's
+// depending on the API key mode of the profile. A non-nil result means no key could be used, and that result should be
+// returned to the agent as-is.
+func SetAPIKeyHeader(ctx context.Context, httpReq *http.Request, portkey config.Portkey) *mcp.CallToolResult {
+	apiKey, err := apikey.Resolve(ctx, portkey)
+	if err != nil {
+		middleware.GetLogger(ctx).Info("no portkey api key to make request with", "error", err)
+
+		return mcp.NewToolResultError(fmt.Sprintf("a Portkey API key is required for the %q profile: provide your "+
+			"own in the %s header, or in the initialize request's experimental %q capability", portkey.Profile,
+			portkey.APIKeyHeader, apikey.ExperimentalCapability))
+	}
+
+	httpReq.Header.Set("X-Portkey-Api-Key", string(apiKey))
+
+	return nil
+}
+
+// CallPortkeyAPI sends the request to Portkey and unmarshals a successful (2xx) response body into out, unless out is
+// nil. A non-nil result means the call failed and that result should be returned to the agent as-is. Error details
+// are logged, while the returned result only carries a generic message.
+func CallPortkeyAPI(
+	ctx context.Context,
+	portkey config.Portkey,
+	portkeyReq PortkeyRequest,
+	out any,
+) *mcp.CallToolResult {
+	lgr := middleware.GetLogger(ctx)
+
+	var body io.Reader
+
+	if portkeyReq.Body != nil {
+		data, err := json.Marshal(portkeyReq.Body)
+		if err != nil {
+			lgr.Error("failed to create request body", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, portkeyReq.Method, portkeyReq.URL, body)
+	if err != nil {
+		lgr.Error("failed to create http request", "error", err)
+
+		return mcp.NewToolResultError(errTextInternalError)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if errResult := SetAPIKeyHeader(ctx, httpReq, portkey); errResult != nil {
+		return errResult
+	}
+
+	resp, err := MakePortkeyAPIRequest(ctx, httpReq)
+	if err != nil {
+		return HandleRequestError(err, lgr)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		lgr.Error("failed to read response body", "error", err)
+
+		return mcp.NewToolResultError("failed to process portkey response")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return HandleHTTPError(resp, respBody, lgr)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		lgr.Error("invalid response format received from portkey service", "error", err)
+
+		return mcp.NewToolResultError("received invalid response from portkey service")
+	}
+
+	return nil
+}
+
+// ResultError converts the error result of CallPortkeyAPI to an error, for callers that aren't tool handlers. Details
+// have already been logged, so the error only carries the result's generic message.
+func ResultError(result *mcp.CallToolResult) error {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return fmt.Errorf("%w: %s", ErrPortkeyRequestFailed, text.Text)
+		}
+	}
+
+	return ErrPortkeyRequestFailed
+}
+
+// NewToolResultJSON returns a typed result to the agent as structured content, along with its JSON text for clients
+// that don't support structured content.
+func NewToolResultJSON(lgr *slog.Logger, v any) *mcp.CallToolResult {
+	data, err := json.Marshal(v)
+	if err != nil {
+		lgr.Error("failed to marshal tool result", "error", err)
+
+		return mcp.NewToolResultError(errTextInternalError)
+	}
+
+	return mcp.NewToolResultStructured(v, string(data))
+}
+
+// HandleRequestError returns the result for a Portkey request that got no response. When Portkey's circuit breaker
+// is open, the request wasn't even made, and the agent is told that Portkey is unavailable for now.
+func HandleRequestError(err error, lgr *slog.Logger) *mcp.CallToolResult {
+	if errors.Is(err, breaker.ErrOpen) {
+		lgr.Warn("portkey request not made", "error", err)
+
+		return mcp.NewToolResultError("portkey temporarily unavailable, try again later")
+	}
+
+	lgr.Error("failed to call portkey api", "error", err)
+
+	return mcp.NewToolResultError("failed to communicate with portkey service")
+}
+
 func HandleHTTPError(resp *http.Response, respBody []byte, lgr *slog.Logger) *mcp.CallToolResult {
 	switch {
 	case resp.StatusCode == http.StatusUnauthorized:
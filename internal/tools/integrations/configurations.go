@@ -0,0 +1,85 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const maskedValue = "****"
+
+// sensitiveKeyParts are substrings of configuration keys whose values are credentials, like aws_secret_access_key or
+// vertex_service_account_json.
+//
+//nolint:gochecknoglobals
+var sensitiveKeyParts = []string{"secret", "key", "token", "password", "credential", "service_account"}
+
+// Configurations holds the provider-specific settings of an integration, such as an Azure resource name or AWS
+// credentials. Like types.MaskedString, the values of credential keys are masked when marshaled, so they can't leak
+// into logs or tool results. Reveal returns the unmasked settings for the request sent to Portkey.
+type Configurations map[string]any
+
+// MarshalJSON implements the json.Marshaler interface to mask credential values in JSON output.
+func (c Configurations) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(mask(c))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal masked configurations to json: %w", err)
+	}
+
+	return data, nil
+}
+
+// Reveal returns the configurations with their credential values unmasked.
+func (c Configurations) Reveal() map[string]any {
+	return c
+}
+
+// mask returns a copy of the settings with the values of sensitive keys replaced, recursing into nested objects and
+// arrays, like a list of deployments that each have their own key.
+func mask(settings map[string]any) map[string]any {
+	if settings == nil {
+		return nil
+	}
+
+	masked := make(map[string]any, len(settings))
+
+	for key, value := range settings {
+		if isSensitiveKey(key) && value != nil && value != "" {
+			masked[key] = maskedValue
+		} else {
+			masked[key] = maskNested(value)
+		}
+	}
+
+	return masked
+}
+
+// maskNested masks the objects within a value that isn't itself a credential.
+func maskNested(value any) any {
+	switch nested := value.(type) {
+	case map[string]any:
+		return mask(nested)
+	case []any:
+		masked := make([]any, len(nested))
+
+		for i, element := range nested {
+			masked[i] = maskNested(element)
+		}
+
+		return masked
+	default:
+		return value
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package integrations_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/integrations"
+)
+
+func TestCredentialsAreMasked(t *testing.T) {
+	t.Parallel()
+
+	req := integrations.CreateRequest{
+		Name:         "bedrock-prod",
+		AIProviderID: "bedrock",
+		Key:          "sk-super-secret",
+		Configurations: integrations.Configurations{
+			"aws_region":            "us-east-1",
+			"aws_secret_access_key": "aws-super-secret",
+			"vertex_service_account_json": map[string]any{
+				"private_key": "pem-super-secret",
+			},
+			"azure": map[string]any{
+				"resource_name": "my-resource",
+				"api_token":     "azure-super-secret",
+			},
+			"deployments": []any{
+				map[string]any{"deployment_name": "gpt-4o-east", "api_key": "east-super-secret"},
+				map[string]any{"deployment_name": "gpt-4o-west", "api_key": "west-super-secret"},
+			},
+			"credentials_list": []any{map[string]any{"api_key": "listed-super-secret"}},
+		},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	if strings.Contains(string(data), "super-secret") {
+		t.Errorf("credentials leaked into JSON: %s", data)
+	}
+
+	for _, visible := range []string{"us-east-1", "my-resource", "bedrock-prod", "gpt-4o-east", "gpt-4o-west"} {
+		if !strings.Contains(string(data), visible) {
+			t.Errorf("expected %q to stay visible in JSON: %s", visible, data)
+		}
+	}
+
+	if req.Configurations.Reveal()["aws_secret_access_key"] != "aws-super-secret" {
+		t.Errorf("expected revealed configurations to be unmasked")
+	}
+}
@@ -0,0 +1,82 @@
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+const (
+	// Tool arguments shared by several integration tools.
+	toolArgIntegrationID  = "integration_id"
+	toolArgName           = "name"
+	toolArgDescription    = "description"
+	toolArgKey            = "key"
+	toolArgConfigurations = "configurations"
+	toolArgWorkspaceID    = "workspace_id"
+
+	// Sub-resources of an integration.
+	pathWorkspaces = "workspaces"
+	pathModels     = "models"
+)
+
+var ErrIntegrationIDRequired = errors.New("integration_id is required")
+
+func withIntegrationID() mcp.ToolOption {
+	return mcp.WithString(toolArgIntegrationID,
+		mcp.Required(),
+		mcp.Description("The ID or slug of the integration."),
+	)
+}
+
+func withKey(description string) mcp.ToolOption {
+	return mcp.WithString(toolArgKey,
+		mcp.Description(description+" The key is never logged or returned."),
+	)
+}
+
+func withConfigurations(description string) mcp.ToolOption {
+	return mcp.WithObject(toolArgConfigurations,
+		mcp.Description(description+" Credential values are never logged or returned."),
+	)
+}
+
+func getIntegrationID(request mcp.CallToolRequest) (string, error) {
+	integrationID := mcp.ParseString(request, toolArgIntegrationID, "")
+	if integrationID == "" {
+		return "", ErrIntegrationIDRequired
+	}
+
+	return integrationID, nil
+}
+
+// getCredentials parses the optional key and configurations arguments.
+func getCredentials(request mcp.CallToolRequest) (types.MaskedString, Configurations, error) {
+	key := types.MaskedString(mcp.ParseString(request, toolArgKey, ""))
+
+	var configurations Configurations
+	if _, err := tools.DecodeArgument(request, toolArgConfigurations, &configurations); err != nil {
+		return "", nil, err
+	}
+
+	return key, configurations, nil
+}
+
+// createURL returns the URL of the integrations collection, of a single integration when an ID is provided, or of
+// one of its sub-resources.
+func createURL(portkey config.Portkey, integrationID, subResource string) string {
+	switch {
+	case integrationID == "":
+		return portkey.BaseURL + "/integrations"
+	case subResource == "":
+		return fmt.Sprintf("%s/integrations/%s", portkey.BaseURL, url.PathEscape(integrationID))
+	default:
+		return fmt.Sprintf("%s/integrations/%s/%s", portkey.BaseURL, url.PathEscape(integrationID), subResource)
+	}
+}
@@ -0,0 +1,111 @@
+package integrations
+
+import "github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+
+// CreateRequest represents the request body for the Portkey Create Integration API. Its credentials are masked when
+// marshaled; the body actually sent to Portkey is built by wireBody.
+type CreateRequest struct {
+	// Required arguments
+	Name         string `json:"name"`
+	AIProviderID string `json:"ai_provider_id"`
+
+	// Optional arguments
+	Slug           string             `json:"slug,omitempty"`
+	Description    string             `json:"description,omitempty"`
+	WorkspaceID    string             `json:"workspace_id,omitempty"`
+	Key            types.MaskedString `json:"key,omitempty"`
+	Configurations Configurations     `json:"configurations,omitempty"`
+}
+
+// UpdateRequest represents the request body for the Portkey Update Integration API. Its credentials are masked when
+// marshaled; the body actually sent to Portkey is built by wireBody.
+type UpdateRequest struct {
+	// Optional arguments
+	Name           string             `json:"name,omitempty"`
+	Description    string             `json:"description,omitempty"`
+	Key            types.MaskedString `json:"key,omitempty"`
+	Configurations Configurations     `json:"configurations,omitempty"`
+}
+
+// WorkspacesUpdateRequest represents the request body for the Portkey Update Integration Workspaces API.
+type WorkspacesUpdateRequest struct {
+	GlobalWorkspaceAccess           *GlobalWorkspaceAccess `json:"global_workspace_access,omitempty"`
+	OverrideExistingWorkspaceAccess *bool                  `json:"override_existing_workspace_access,omitempty"`
+	Workspaces                      []WorkspaceAccess      `json:"workspaces,omitempty"`
+}
+
+// GlobalWorkspaceAccess controls whether every workspace, including ones created later, can use an integration.
+type GlobalWorkspaceAccess struct {
+	Enabled     bool          `json:"enabled"`
+	UsageLimits []UsageLimits `json:"usage_limits,omitempty"`
+	RateLimits  []RateLimits  `json:"rate_limits,omitempty"`
+}
+
+// WorkspaceAccess controls whether a single workspace can use an integration, and within which limits.
+type WorkspaceAccess struct {
+	ID          string        `json:"id"`
+	Enabled     bool          `json:"enabled"`
+	UsageLimits []UsageLimits `json:"usage_limits,omitempty"`
+	RateLimits  []RateLimits  `json:"rate_limits,omitempty"`
+	ResetUsage  *bool         `json:"reset_usage,omitempty"`
+}
+
+// UsageLimits caps the cost or tokens a workspace may consume through an integration.
+type UsageLimits struct {
+	Type           string   `json:"type"`
+	CreditLimit    float64  `json:"credit_limit"`
+	AlertThreshold *float64 `json:"alert_threshold,omitempty"`
+	PeriodicReset  string   `json:"periodic_reset,omitempty"`
+}
+
+// RateLimits caps the requests or tokens per minute, hour or day a workspace may use through an integration.
+type RateLimits struct {
+	Type  string  `json:"type"`
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// ModelsUpdateRequest represents the request body for the Portkey Update Integration Models API.
+type ModelsUpdateRequest struct {
+	AllowAllModels *bool   `json:"allow_all_models,omitempty"`
+	Models         []Model `json:"models,omitempty"`
+}
+
+// createBody and updateBody are the unmasked bodies sent to Portkey. They must never be logged.
+type createBody struct {
+	Name           string         `json:"name"`
+	AIProviderID   string         `json:"ai_provider_id"`
+	Slug           string         `json:"slug,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	WorkspaceID    string         `json:"workspace_id,omitempty"`
+	Key            string         `json:"key,omitempty"`
+	Configurations map[string]any `json:"configurations,omitempty"`
+}
+
+type updateBody struct {
+	Name           string         `json:"name,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	Key            string         `json:"key,omitempty"`
+	Configurations map[string]any `json:"configurations,omitempty"`
+}
+
+func (r CreateRequest) wireBody() createBody {
+	return createBody{
+		Name:           r.Name,
+		AIProviderID:   r.AIProviderID,
+		Slug:           r.Slug,
+		Description:    r.Description,
+		WorkspaceID:    r.WorkspaceID,
+		Key:            string(r.Key),
+		Configurations: r.Configurations.Reveal(),
+	}
+}
+
+func (r UpdateRequest) wireBody() updateBody {
+	return updateBody{
+		Name:           r.Name,
+		Description:    r.Description,
+		Key:            string(r.Key),
+		Configurations: r.Configurations.Reveal(),
+	}
+}
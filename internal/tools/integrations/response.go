@@ -0,0 +1,58 @@
+package integrations
+
+import "time"
+
+// ListResponse represents the full response structure from the Portkey List Integrations API.
+type ListResponse struct {
+	Data  []Integration `json:"data"`
+	Total int           `json:"total"`
+}
+
+// Integration represents a set of provider credentials, owned by the organisation or a workspace.
+type Integration struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Slug           string         `json:"slug"`
+	Description    string         `json:"description,omitempty"`
+	AIProviderID   string         `json:"ai_provider_id"`
+	Status         string         `json:"status"`
+	OrganisationID string         `json:"organisation_id,omitempty"`
+	WorkspaceID    string         `json:"workspace_id,omitempty"`
+	MaskedKey      string         `json:"masked_key,omitempty"`
+	Configurations Configurations `json:"configurations,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastUpdatedAt  time.Time      `json:"last_updated_at"`
+}
+
+// MutationResponse represents the response from the Portkey Create and Update Integration APIs.
+type MutationResponse struct {
+	ID   string `json:"id,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// WorkspacesResponse represents the response from the Portkey List Integration Workspaces API.
+type WorkspacesResponse struct {
+	Total      int               `json:"total"`
+	Workspaces []WorkspaceAccess `json:"workspaces"`
+}
+
+// ModelsResponse represents the response from the Portkey List Integration Models API.
+type ModelsResponse struct {
+	Total          int     `json:"total"`
+	AllowAllModels bool    `json:"allow_all_models"`
+	Data           []Model `json:"data"`
+}
+
+// Model is a model an integration can expose to the workspaces that use it.
+type Model struct {
+	Slug          string         `json:"slug"`
+	Enabled       bool           `json:"enabled"`
+	IsCustom      *bool          `json:"is_custom,omitempty"`
+	PricingConfig map[string]any `json:"pricing_config,omitempty"`
+}
+
+// AccessUpdateResponse is returned by the tools that update an integration's workspaces or models.
+type AccessUpdateResponse struct {
+	Slug    string `json:"slug"`
+	Updated bool   `json:"updated"`
+}
@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	createToolName = "integration_create"
+
+	// Tool arguments.
+	toolArgAIProviderID = "ai_provider_id"
+	toolArgSlug         = "slug"
+)
+
+var (
+	ErrNameRequired         = errors.New("name is required")
+	ErrAIProviderIDRequired = errors.New("ai_provider_id is required")
+)
+
+func NewCreateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Create a Portkey integration that stores the credentials for an AI provider, so they can be " +
+		"shared with workspaces. Created at the organisation level unless a workspace_id is given."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	createTool := mcp.NewTool(
+		createToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Integration"),
+		mcp.WithOutputSchema[MutationResponse](),
+		mcp.WithString(toolArgName,
+			mcp.Required(),
+			mcp.Description("Name of the integration to create."),
+		),
+		mcp.WithString(toolArgAIProviderID,
+			mcp.Required(),
+			mcp.Description("The AI provider the credentials are for, e.g. 'openai', 'anthropic', 'azure-openai' or "+
+				"'bedrock'."),
+		),
+		mcp.WithString(toolArgSlug,
+			mcp.Description("Optional. A unique slug for the integration. Generated from the name if omitted."),
+		),
+		mcp.WithString(toolArgDescription,
+			mcp.Description("Optional. A description of the integration."),
+		),
+		withKey("Optional. The provider API key."),
+		withConfigurations("Optional. Provider-specific settings, e.g. Azure resource details or AWS credentials."),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Create a workspace-level integration in this workspace."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: createHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// createHandler calls the Portkey Create Integration API and returns the result.
+func createHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, "", ""),
+			Body:   req.wireBody(),
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getCreateToolArguments(request mcp.CallToolRequest) (CreateRequest, error) {
+	name := mcp.ParseString(request, toolArgName, "")
+	if name == "" {
+		return CreateRequest{}, ErrNameRequired
+	}
+
+	aiProviderID := mcp.ParseString(request, toolArgAIProviderID, "")
+	if aiProviderID == "" {
+		return CreateRequest{}, ErrAIProviderIDRequired
+	}
+
+	key, configurations, err := getCredentials(request)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	return CreateRequest{
+		Name:           name,
+		AIProviderID:   aiProviderID,
+		Slug:           mcp.ParseString(request, toolArgSlug, ""),
+		Description:    mcp.ParseString(request, toolArgDescription, ""),
+		WorkspaceID:    mcp.ParseString(request, toolArgWorkspaceID, ""),
+		Key:            key,
+		Configurations: configurations,
+	}, nil
+}
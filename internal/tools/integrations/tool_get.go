@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const getToolName = "integration_get"
+
+func NewGetTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Retrieve a single Portkey integration, including its provider and configuration. Credentials " +
+		"are masked."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	getTool := mcp.NewTool(
+		getToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Get Integration"),
+		mcp.WithOutputSchema[Integration](),
+		withIntegrationID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &getTool,
+		Handler: getHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// getHandler calls the Portkey Retrieve Integration API and returns the result.
+func getHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		integrationID, err := getIntegrationID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp Integration
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createURL(portkey, integrationID, ""),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
@@ -0,0 +1,170 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	listToolName = "integrations_list"
+
+	// Tool arguments.
+	toolArgType        = "type"
+	toolArgCurrentPage = "current_page"
+	toolArgPageSize    = "page_size"
+
+	// Portkey API query parameters.
+	apiParamWorkspaceID = "workspace_id"
+	apiParamType        = "type"
+	apiParamCurrentPage = "current_page"
+	apiParamPageSize    = "page_size"
+)
+
+var (
+	ErrInvalidType        = fmt.Errorf("%s must be one of: %s", toolArgType, strings.Join(integrationTypes, ", "))
+	ErrInvalidPageSize    = fmt.Errorf("%s must be a positive integer", toolArgPageSize)
+	ErrInvalidCurrentPage = fmt.Errorf("%s must be a positive integer", toolArgCurrentPage)
+)
+
+//nolint:gochecknoglobals
+var integrationTypes = []string{"organisation", "workspace", "all"}
+
+type listToolArgs struct {
+	workspaceID     string
+	integrationType string
+	currentPage     *int
+	pageSize        *int
+}
+
+func NewListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the integrations in your Portkey account. An integration stores the credentials for an AI " +
+		"provider once, at the organisation or workspace level, and shares them with the workspaces allowed to use " +
+		"it. Credentials are never included in the results."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	listTool := mcp.NewTool(
+		listToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Integrations"),
+		mcp.WithOutputSchema[ListResponse](),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Only list integrations available to this workspace."),
+		),
+		mcp.WithString(toolArgType,
+			mcp.Description("Optional. Filter by where the integration is owned. Defaults to all."),
+			mcp.Enum(integrationTypes...),
+		),
+		mcp.WithNumber(toolArgCurrentPage,
+			mcp.Description("Optional. Page number for pagination. Starts at 1."),
+		),
+		mcp.WithNumber(toolArgPageSize,
+			mcp.Description("Optional. Number of results per page."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &listTool,
+		Handler: listHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// listHandler calls the Portkey List Integrations API and returns the result.
+func listHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getListToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp ListResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createListURL(portkey, args),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getListToolArguments(request mcp.CallToolRequest) (listToolArgs, error) {
+	integrationType := mcp.ParseString(request, toolArgType, "")
+	if integrationType != "" && !slices.Contains(integrationTypes, integrationType) {
+		return listToolArgs{}, ErrInvalidType
+	}
+
+	//nolint:exhaustruct
+	args := listToolArgs{
+		workspaceID:     mcp.ParseString(request, toolArgWorkspaceID, ""),
+		integrationType: integrationType,
+	}
+
+	// Handle optional integer arguments.
+	currentPage := mcp.ParseInt(request, toolArgCurrentPage, 0)
+	if currentPage > 0 {
+		args.currentPage = &currentPage
+	} else if currentPage < 0 {
+		return listToolArgs{}, ErrInvalidCurrentPage
+	}
+
+	pageSize := mcp.ParseInt(request, toolArgPageSize, 0)
+	if pageSize > 0 {
+		args.pageSize = &pageSize
+	} else if pageSize < 0 {
+		return listToolArgs{}, ErrInvalidPageSize
+	}
+
+	return args, nil
+}
+
+func createListURL(portkey config.Portkey, args listToolArgs) string {
+	baseURL := createURL(portkey, "", "")
+
+	// Add query parameters.
+	values := url.Values{}
+	if args.workspaceID != "" {
+		values.Add(apiParamWorkspaceID, args.workspaceID)
+	}
+
+	if args.integrationType != "" {
+		values.Add(apiParamType, args.integrationType)
+	}
+
+	if args.currentPage != nil {
+		values.Add(apiParamCurrentPage, strconv.Itoa(*args.currentPage))
+	}
+
+	if args.pageSize != nil {
+		values.Add(apiParamPageSize, strconv.Itoa(*args.pageSize))
+	}
+
+	if len(values) > 0 {
+		return baseURL + "?" + values.Encode()
+	}
+
+	return baseURL
+}
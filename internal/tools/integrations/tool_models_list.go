@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const modelsListToolName = "integration_models_list"
+
+func NewModelsListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the models a Portkey integration exposes to the workspaces that use it, and whether every " +
+		"model of the provider is allowed."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	modelsListTool := mcp.NewTool(
+		modelsListToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Integration Models"),
+		mcp.WithOutputSchema[ModelsResponse](),
+		withIntegrationID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &modelsListTool,
+		Handler: modelsListHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// modelsListHandler calls the Portkey List Integration Models API and returns the result.
+func modelsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		integrationID, err := getIntegrationID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp ModelsResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createURL(portkey, integrationID, pathModels),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
@@ -0,0 +1,137 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	modelsUpdateToolName = "integration_models_update"
+
+	// Tool arguments.
+	toolArgAllowAllModels = "allow_all_models"
+	toolArgModels         = "models"
+)
+
+var (
+	ErrNothingToUpdateModels = errors.New("at least one of allow_all_models or models is required")
+	ErrModelSlugRequired     = errors.New("every model must have a slug")
+)
+
+//nolint:gochecknoglobals
+var modelSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"slug":    map[string]any{"type": "string", "description": "The model name, e.g. 'gpt-4o'."},
+		"enabled": map[string]any{"type": "boolean"},
+		"is_custom": map[string]any{
+			"type":        "boolean",
+			"description": "Whether this is a custom or fine-tuned model the provider doesn't list.",
+		},
+		"pricing_config": map[string]any{
+			"type":        "object",
+			"description": "Optional pricing used for cost tracking of custom models.",
+		},
+	},
+	"required": []string{"slug", "enabled"},
+}
+
+type modelsUpdateToolArgs struct {
+	integrationID string
+	request       ModelsUpdateRequest
+}
+
+func NewModelsUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Control which models a Portkey integration exposes: allow every model of the provider, or " +
+		"enable and disable individual models, including custom ones."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	modelsUpdateTool := mcp.NewTool(
+		modelsUpdateToolName,
+		mcp.WithDescription(description),
+		tools.UpdateAnnotation("Update Integration Models"),
+		mcp.WithOutputSchema[AccessUpdateResponse](),
+		withIntegrationID(),
+		mcp.WithBoolean(toolArgAllowAllModels,
+			mcp.Description("Optional. Allow every model of the provider, including ones released later."),
+		),
+		mcp.WithArray(toolArgModels,
+			mcp.Description("Optional. Models to enable or disable. Models not listed keep their current setting."),
+			mcp.Items(modelSchema),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &modelsUpdateTool,
+		Handler: modelsUpdateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// modelsUpdateHandler calls the Portkey Update Integration Models API and returns the result.
+func modelsUpdateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getModelsUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, args.integrationID, pathModels),
+			Body:   args.request,
+		}, nil)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, AccessUpdateResponse{Slug: args.integrationID, Updated: true}), nil
+	}
+}
+
+func getModelsUpdateToolArguments(request mcp.CallToolRequest) (modelsUpdateToolArgs, error) {
+	integrationID, err := getIntegrationID(request)
+	if err != nil {
+		return modelsUpdateToolArgs{}, err
+	}
+
+	var models []Model
+	if _, err := tools.DecodeArgument(request, toolArgModels, &models); err != nil {
+		return modelsUpdateToolArgs{}, err
+	}
+
+	for _, model := range models {
+		if model.Slug == "" {
+			return modelsUpdateToolArgs{}, ErrModelSlugRequired
+		}
+	}
+
+	//nolint:exhaustruct
+	req := ModelsUpdateRequest{Models: models}
+
+	if mcp.ParseArgument(request, toolArgAllowAllModels, nil) != nil {
+		allowAll := mcp.ParseBoolean(request, toolArgAllowAllModels, false)
+		req.AllowAllModels = &allowAll
+	}
+
+	if req.AllowAllModels == nil && len(models) == 0 {
+		return modelsUpdateToolArgs{}, ErrNothingToUpdateModels
+	}
+
+	return modelsUpdateToolArgs{integrationID: integrationID, request: req}, nil
+}
@@ -0,0 +1,106 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const updateToolName = "integration_update"
+
+var ErrNothingToUpdate = errors.New("at least one of name, description, key or configurations is required")
+
+type updateToolArgs struct {
+	integrationID string
+	request       UpdateRequest
+}
+
+func NewUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Update the name, description or credentials of an existing Portkey integration. Use this to " +
+		"rotate a provider API key without touching the workspaces that use it."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	updateTool := mcp.NewTool(
+		updateToolName,
+		mcp.WithDescription(description),
+		tools.UpdateAnnotation("Update Integration"),
+		mcp.WithOutputSchema[MutationResponse](),
+		withIntegrationID(),
+		mcp.WithString(toolArgName,
+			mcp.Description("Optional. The new name of the integration."),
+		),
+		mcp.WithString(toolArgDescription,
+			mcp.Description("Optional. The new description of the integration."),
+		),
+		withKey("Optional. A new provider API key, replacing the existing one."),
+		withConfigurations("Optional. New provider-specific settings, replacing the existing ones."),
+	)
+
+	return tools.Tuple{
+		Tool:    &updateTool,
+		Handler: updateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// updateHandler calls the Portkey Update Integration API and returns the result.
+func updateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, args.integrationID, ""),
+			Body:   args.request.wireBody(),
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getUpdateToolArguments(request mcp.CallToolRequest) (updateToolArgs, error) {
+	integrationID, err := getIntegrationID(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	key, configurations, err := getCredentials(request)
+	if err != nil {
+		return updateToolArgs{}, err
+	}
+
+	req := UpdateRequest{
+		Name:           mcp.ParseString(request, toolArgName, ""),
+		Description:    mcp.ParseString(request, toolArgDescription, ""),
+		Key:            key,
+		Configurations: configurations,
+	}
+
+	if req.Name == "" && req.Description == "" && req.Key == "" && len(req.Configurations) == 0 {
+		return updateToolArgs{}, ErrNothingToUpdate
+	}
+
+	return updateToolArgs{integrationID: integrationID, request: req}, nil
+}
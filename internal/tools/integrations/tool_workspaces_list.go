@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const workspacesListToolName = "integration_workspaces_list"
+
+func NewWorkspacesListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the workspaces that can use a Portkey integration, along with the usage and rate limits " +
+		"each workspace has on it."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	workspacesListTool := mcp.NewTool(
+		workspacesListToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Integration Workspaces"),
+		mcp.WithOutputSchema[WorkspacesResponse](),
+		withIntegrationID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &workspacesListTool,
+		Handler: workspacesListHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// workspacesListHandler calls the Portkey List Integration Workspaces API and returns the result.
+func workspacesListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		integrationID, err := getIntegrationID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp WorkspacesResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createURL(portkey, integrationID, pathWorkspaces),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
@@ -0,0 +1,187 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	workspacesUpdateToolName = "integration_workspaces_update"
+
+	// Tool arguments.
+	toolArgGlobalWorkspaceAccess           = "global_workspace_access"
+	toolArgOverrideExistingWorkspaceAccess = "override_existing_workspace_access"
+	toolArgWorkspaces                      = "workspaces"
+)
+
+var (
+	ErrNothingToUpdateWorkspaces = errors.New("at least one of global_workspace_access or workspaces is required")
+	ErrWorkspaceIDRequired       = errors.New("every workspace must have an id")
+)
+
+//nolint:gochecknoglobals
+var (
+	usageLimitsSchema = map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type":            map[string]any{"type": "string", "enum": []string{"cost", "tokens"}},
+				"credit_limit":    map[string]any{"type": "number"},
+				"alert_threshold": map[string]any{"type": "number"},
+				"periodic_reset":  map[string]any{"type": "string", "enum": []string{"monthly", "weekly"}},
+			},
+			"required": []string{"type", "credit_limit"},
+		},
+	}
+
+	rateLimitsSchema = map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type":  map[string]any{"type": "string", "enum": []string{"requests", "tokens"}},
+				"unit":  map[string]any{"type": "string", "enum": []string{"rpm", "rph", "rpd"}},
+				"value": map[string]any{"type": "number"},
+			},
+			"required": []string{"type", "unit", "value"},
+		},
+	}
+
+	workspaceAccessSchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":           map[string]any{"type": "string"},
+			"enabled":      map[string]any{"type": "boolean"},
+			"usage_limits": usageLimitsSchema,
+			"rate_limits":  rateLimitsSchema,
+			"reset_usage": map[string]any{
+				"type":        "boolean",
+				"description": "Reset the workspace's usage of the integration to zero.",
+			},
+		},
+		"required": []string{"id", "enabled"},
+	}
+)
+
+type workspacesUpdateToolArgs struct {
+	integrationID string
+	request       WorkspacesUpdateRequest
+}
+
+func NewWorkspacesUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Control which workspaces can use a Portkey integration, and the usage and rate limits each " +
+		"workspace has on it. Either grant access to every workspace with global_workspace_access, or list " +
+		"individual workspaces."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	workspacesUpdateTool := mcp.NewTool(
+		workspacesUpdateToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Update Integration Workspaces"),
+		mcp.WithOutputSchema[AccessUpdateResponse](),
+		withIntegrationID(),
+		mcp.WithObject(toolArgGlobalWorkspaceAccess,
+			mcp.Description("Optional. Enable or disable the integration for every workspace, including ones "+
+				"created later."),
+			mcp.Properties(map[string]any{
+				"enabled":      map[string]any{"type": "boolean"},
+				"usage_limits": usageLimitsSchema,
+				"rate_limits":  rateLimitsSchema,
+			}),
+		),
+		mcp.WithBoolean(toolArgOverrideExistingWorkspaceAccess,
+			mcp.Description("Optional. Also apply global_workspace_access to workspaces with their own settings."),
+		),
+		mcp.WithArray(toolArgWorkspaces,
+			mcp.Description("Optional. Access settings for individual workspaces. Workspaces not listed keep their "+
+				"current settings."),
+			mcp.Items(workspaceAccessSchema),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &workspacesUpdateTool,
+		Handler: workspacesUpdateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// workspacesUpdateHandler calls the Portkey Update Integration Workspaces API and returns the result.
+func workspacesUpdateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getWorkspacesUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, args.integrationID, pathWorkspaces),
+			Body:   args.request,
+		}, nil)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, AccessUpdateResponse{Slug: args.integrationID, Updated: true}), nil
+	}
+}
+
+func getWorkspacesUpdateToolArguments(request mcp.CallToolRequest) (workspacesUpdateToolArgs, error) {
+	integrationID, err := getIntegrationID(request)
+	if err != nil {
+		return workspacesUpdateToolArgs{}, err
+	}
+
+	var globalAccess GlobalWorkspaceAccess
+
+	hasGlobalAccess, err := tools.DecodeArgument(request, toolArgGlobalWorkspaceAccess, &globalAccess)
+	if err != nil {
+		return workspacesUpdateToolArgs{}, err
+	}
+
+	var workspaces []WorkspaceAccess
+	if _, err := tools.DecodeArgument(request, toolArgWorkspaces, &workspaces); err != nil {
+		return workspacesUpdateToolArgs{}, err
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.ID == "" {
+			return workspacesUpdateToolArgs{}, ErrWorkspaceIDRequired
+		}
+	}
+
+	if !hasGlobalAccess && len(workspaces) == 0 {
+		return workspacesUpdateToolArgs{}, ErrNothingToUpdateWorkspaces
+	}
+
+	//nolint:exhaustruct
+	req := WorkspacesUpdateRequest{Workspaces: workspaces}
+
+	if hasGlobalAccess {
+		req.GlobalWorkspaceAccess = &globalAccess
+	}
+
+	if mcp.ParseArgument(request, toolArgOverrideExistingWorkspaceAccess, nil) != nil {
+		override := mcp.ParseBoolean(request, toolArgOverrideExistingWorkspaceAccess, false)
+		req.OverrideExistingWorkspaceAccess = &override
+	}
+
+	return workspacesUpdateToolArgs{integrationID: integrationID, request: req}, nil
+}
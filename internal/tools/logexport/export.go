@@ -0,0 +1,54 @@
+package logexport
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+const toolArgExportID = "export_id"
+
+var ErrExportIDRequired = errors.New("export_id is required")
+
+// requestedDataFields are the log fields that may be included in an export.
+var requestedDataFields = []string{ //nolint:gochecknoglobals
+	"id", "trace_id", "created_at", "request", "response", "is_success", "ai_org", "ai_model", "req_units",
+	"res_units", "total_units", "request_url", "cost", "cost_currency", "response_time", "response_status_code",
+	"mode", "config", "prompt_slug", "metadata",
+}
+
+func withExportID() mcp.ToolOption {
+	return mcp.WithString(toolArgExportID,
+		mcp.Required(),
+		mcp.Description("The ID of the log export, as returned by the log_export_create tool."),
+	)
+}
+
+func getExportID(request mcp.CallToolRequest) (string, error) {
+	exportID := mcp.ParseString(request, toolArgExportID, "")
+	if exportID == "" {
+		return "", ErrExportIDRequired
+	}
+
+	return exportID, nil
+}
+
+// createURL returns the URL of the log exports collection, or of a single export (and optional action) when an export
+// ID is provided.
+func createURL(portkey config.Portkey, exportID string, action string) string {
+	apiURL := portkey.BaseURL + "/logs/exports"
+
+	if exportID != "" {
+		apiURL = fmt.Sprintf("%s/%s", apiURL, url.PathEscape(exportID))
+	}
+
+	if action != "" {
+		apiURL = fmt.Sprintf("%s/%s", apiURL, action)
+	}
+
+	return apiURL
+}
@@ -0,0 +1,29 @@
+package logexport
+
+import "time"
+
+// CreateRequest represents the request body for the Portkey Create Log Export API.
+type CreateRequest struct {
+	// Required arguments
+	Filters       Filters  `json:"filters"`
+	RequestedData []string `json:"requested_data"`
+
+	// Optional arguments
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Filters selects the logs that are included in an export.
+type Filters struct {
+	TimeOfGenerationMin *time.Time        `json:"time_of_generation_min,omitempty"`
+	TimeOfGenerationMax *time.Time        `json:"time_of_generation_max,omitempty"`
+	TraceID             string            `json:"trace_id,omitempty"`
+	StatusCode          string            `json:"status_code,omitempty"`
+	AIModel             string            `json:"ai_org_model,omitempty"`
+	VirtualKeys         string            `json:"virtual_keys,omitempty"`
+	Configs             string            `json:"configs,omitempty"`
+	PromptSlug          string            `json:"prompt_slug,omitempty"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+	CostMin             *float64          `json:"cost_min,omitempty"`
+	CostMax             *float64          `json:"cost_max,omitempty"`
+}
@@ -0,0 +1,51 @@
+package logexport
+
+import "time"
+
+// Export job statuses reported by Portkey.
+const (
+	StatusDraft      = "draft"
+	StatusInProgress = "in_progress"
+	StatusSuccess    = "success"
+	StatusFailed     = "failed"
+	StatusStopped    = "stopped"
+)
+
+// CreateResponse represents the response from the Portkey Create Log Export API.
+type CreateResponse struct {
+	ID     string `json:"id"`
+	Total  int    `json:"total"`
+	Object string `json:"object"`
+}
+
+// StartResponse represents the response from the Portkey Start Log Export API.
+type StartResponse struct {
+	Message string `json:"message"`
+	Object  string `json:"object"`
+}
+
+// Export represents the response from the Portkey Retrieve Log Export API.
+type Export struct {
+	ID             string    `json:"id"`
+	OrganisationID string    `json:"organisation_id,omitempty"`
+	WorkspaceID    string    `json:"workspace_id,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	Status         string    `json:"status"`
+	Filters        Filters   `json:"filters"`
+	RequestedData  []string  `json:"requested_data"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastUpdatedAt  time.Time `json:"last_updated_at"`
+	Object         string    `json:"object"`
+}
+
+// DownloadResponse represents the response from the Portkey Download Log Export API.
+type DownloadResponse struct {
+	SignedURL string `json:"signed_url"`
+}
+
+// WaitResponse is returned by log_export_start when asked to wait for the export to finish.
+type WaitResponse struct {
+	Export    Export `json:"export"`
+	SignedURL string `json:"signed_url,omitempty"`
+}
@@ -0,0 +1,215 @@
+package logexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	createToolName = "log_export_create"
+
+	// Tool arguments.
+	toolArgRequestedData       = "requested_data"
+	toolArgWorkspaceID         = "workspace_id"
+	toolArgDescription         = "description"
+	toolArgTimeOfGenerationMin = "time_of_generation_min"
+	toolArgTimeOfGenerationMax = "time_of_generation_max"
+	toolArgTraceID             = "trace_id"
+	toolArgStatusCode          = "status_code"
+	toolArgAIModel             = "ai_model"
+	toolArgVirtualKeys         = "virtual_keys"
+	toolArgConfigs             = "configs"
+	toolArgPromptSlug          = "prompt_slug"
+	toolArgMetadata            = "metadata"
+	toolArgCostMin             = "cost_min"
+	toolArgCostMax             = "cost_max"
+)
+
+var (
+	ErrRequestedDataRequired = errors.New("requested_data is required")
+	ErrUnknownRequestedData  = errors.New("unknown requested_data field")
+	ErrInvalidTimeRange      = fmt.Errorf("%s must not be after %s",
+		toolArgTimeOfGenerationMin, toolArgTimeOfGenerationMax)
+	ErrInvalidCostRange = fmt.Errorf("%s must not be greater than %s", toolArgCostMin, toolArgCostMax)
+)
+
+func NewCreateTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Create an asynchronous export of Portkey gateway logs, for audits and bulk analysis. The export " +
+		"is created in a draft state; use the log_export_start tool to run it, log_export_get to check its status, " +
+		"and log_export_download to get a download URL once it has finished."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	createTool := mcp.NewTool(
+		createToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Log Export"),
+		mcp.WithOutputSchema[CreateResponse](),
+		mcp.WithArray(toolArgRequestedData,
+			mcp.Required(),
+			mcp.Description("The log fields to include in the export."),
+			mcp.Items(map[string]any{"type": "string", "enum": requestedDataFields}),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. The workspace to export logs from."),
+		),
+		mcp.WithString(toolArgDescription,
+			mcp.Description("Optional. A description of the export, e.g. the reason for the audit."),
+		),
+		mcp.WithString(toolArgTimeOfGenerationMin,
+			mcp.Description("Optional. Only export logs generated at or after this time, in RFC 3339 format "+
+				"(e.g. '2025-01-02T15:04:05Z')."),
+		),
+		mcp.WithString(toolArgTimeOfGenerationMax,
+			mcp.Description("Optional. Only export logs generated at or before this time, in RFC 3339 format."),
+		),
+		mcp.WithString(toolArgTraceID,
+			mcp.Description("Optional. Only export logs with this trace ID."),
+		),
+		mcp.WithString(toolArgStatusCode,
+			mcp.Description("Optional. Only export logs with this HTTP status code. Multiple codes may be "+
+				"comma-separated (e.g. '429,500')."),
+		),
+		mcp.WithString(toolArgAIModel,
+			mcp.Description("Optional. Only export logs for this model (e.g. 'gpt-4o')."),
+		),
+		mcp.WithString(toolArgVirtualKeys,
+			mcp.Description("Optional. Only export logs for these virtual key slugs, comma-separated."),
+		),
+		mcp.WithString(toolArgConfigs,
+			mcp.Description("Optional. Only export logs for these config slugs, comma-separated."),
+		),
+		mcp.WithString(toolArgPromptSlug,
+			mcp.Description("Optional. Only export logs for this prompt slug."),
+		),
+		mcp.WithObject(toolArgMetadata,
+			mcp.Description("Optional. Only export logs with this metadata. The object should be a JSON object with "+
+				"key-value pairs of string metadata keys to string values."),
+		),
+		mcp.WithNumber(toolArgCostMin,
+			mcp.Description("Optional. Only export logs that cost at least this much, in cents."),
+		),
+		mcp.WithNumber(toolArgCostMax,
+			mcp.Description("Optional. Only export logs that cost at most this much, in cents."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: createHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// createHandler calls the Portkey Create Log Export API and returns the result.
+func createHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp CreateResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, "", ""),
+			Body:   req,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getCreateToolArguments(request mcp.CallToolRequest) (CreateRequest, error) {
+	requestedData, err := tools.ParseStringSlice(request, toolArgRequestedData)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	if len(requestedData) == 0 {
+		return CreateRequest{}, ErrRequestedDataRequired
+	}
+
+	for _, field := range requestedData {
+		if !slices.Contains(requestedDataFields, field) {
+			return CreateRequest{}, fmt.Errorf("%w: %q", ErrUnknownRequestedData, field)
+		}
+	}
+
+	filters, err := getFilters(request)
+	if err != nil {
+		return CreateRequest{}, err
+	}
+
+	return CreateRequest{
+		Filters:       filters,
+		RequestedData: requestedData,
+		WorkspaceID:   mcp.ParseString(request, toolArgWorkspaceID, ""),
+		Description:   mcp.ParseString(request, toolArgDescription, ""),
+	}, nil
+}
+
+func getFilters(request mcp.CallToolRequest) (Filters, error) {
+	//nolint:exhaustruct
+	filters := Filters{
+		TraceID:     mcp.ParseString(request, toolArgTraceID, ""),
+		StatusCode:  mcp.ParseString(request, toolArgStatusCode, ""),
+		AIModel:     mcp.ParseString(request, toolArgAIModel, ""),
+		VirtualKeys: mcp.ParseString(request, toolArgVirtualKeys, ""),
+		Configs:     mcp.ParseString(request, toolArgConfigs, ""),
+		PromptSlug:  mcp.ParseString(request, toolArgPromptSlug, ""),
+	}
+
+	var err error
+
+	if filters.TimeOfGenerationMin, err = tools.ParseTime(request, toolArgTimeOfGenerationMin); err != nil {
+		return Filters{}, err
+	}
+
+	if filters.TimeOfGenerationMax, err = tools.ParseTime(request, toolArgTimeOfGenerationMax); err != nil {
+		return Filters{}, err
+	}
+
+	if filters.TimeOfGenerationMin != nil && filters.TimeOfGenerationMax != nil &&
+		filters.TimeOfGenerationMin.After(*filters.TimeOfGenerationMax) {
+		return Filters{}, ErrInvalidTimeRange
+	}
+
+	if filters.CostMin, err = tools.ParseNonNegativeFloat(request, toolArgCostMin); err != nil {
+		return Filters{}, err
+	}
+
+	if filters.CostMax, err = tools.ParseNonNegativeFloat(request, toolArgCostMax); err != nil {
+		return Filters{}, err
+	}
+
+	if filters.CostMin != nil && filters.CostMax != nil && *filters.CostMin > *filters.CostMax {
+		return Filters{}, ErrInvalidCostRange
+	}
+
+	if filters.Metadata, err = tools.ParseStringMap(request, toolArgMetadata); err != nil {
+		return Filters{}, err
+	}
+
+	return filters, nil
+}
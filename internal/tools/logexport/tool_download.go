@@ -0,0 +1,71 @@
+package logexport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const downloadToolName = "log_export_download"
+
+func NewDownloadTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Get a signed download URL for a finished Portkey log export. The export must have a status of " +
+		"success; use the log_export_get tool to check."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	downloadTool := mcp.NewTool(
+		downloadToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Download Log Export"),
+		mcp.WithOutputSchema[DownloadResponse](),
+		withExportID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &downloadTool,
+		Handler: downloadHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// downloadHandler calls the Portkey Download Log Export API and returns the result.
+func downloadHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		exportID, err := getExportID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		download, errResult := getDownload(ctx, portkey, exportID)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, download), nil
+	}
+}
+
+func getDownload(ctx context.Context, portkey config.Portkey, exportID string) (DownloadResponse, *mcp.CallToolResult) {
+	var download DownloadResponse
+
+	errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+		Method: http.MethodGet,
+		URL:    createURL(portkey, exportID, "download"),
+		Body:   nil,
+	}, &download)
+
+	return download, errResult
+}
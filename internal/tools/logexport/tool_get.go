@@ -0,0 +1,71 @@
+package logexport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const getToolName = "log_export_get"
+
+func NewGetTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Retrieve a Portkey log export, including its filters, requested fields and current status " +
+		"(draft, in_progress, success, failed or stopped)."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	getTool := mcp.NewTool(
+		getToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Get Log Export"),
+		mcp.WithOutputSchema[Export](),
+		withExportID(),
+	)
+
+	return tools.Tuple{
+		Tool:    &getTool,
+		Handler: getHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// getHandler calls the Portkey Retrieve Log Export API and returns the result.
+func getHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		exportID, err := getExportID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		export, errResult := getExport(ctx, portkey, exportID)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, export), nil
+	}
+}
+
+func getExport(ctx context.Context, portkey config.Portkey, exportID string) (Export, *mcp.CallToolResult) {
+	var export Export
+
+	errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+		Method: http.MethodGet,
+		URL:    createURL(portkey, exportID, ""),
+		Body:   nil,
+	}, &export)
+
+	return export, errResult
+}
@@ -0,0 +1,214 @@
+package logexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	startToolName = "log_export_start"
+
+	// Tool arguments.
+	toolArgWaitForCompletion = "wait_for_completion"
+	toolArgMaxWaitSeconds    = "max_wait_seconds"
+
+	defaultMaxWait = 5 * time.Minute
+	maxMaxWait     = 30 * time.Minute
+
+	methodNotificationProgress = "notifications/progress"
+)
+
+var ErrInvalidMaxWait = fmt.Errorf("%s must be between 1 and %d", toolArgMaxWaitSeconds, int(maxMaxWait.Seconds()))
+
+type startToolArgs struct {
+	exportID          string
+	waitForCompletion bool
+	maxWait           time.Duration
+}
+
+func NewStartTool(portkeyCfg config.Portkey, toolCfg config.LogExportStartTool) tools.Tuple {
+	description := "Start a Portkey log export that was created with the log_export_create tool. Exports run " +
+		"asynchronously. Optionally, this tool can wait for the export to finish, reporting progress while it polls, " +
+		"and return the download URL once the export has succeeded."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	startTool := mcp.NewTool(
+		startToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Start Log Export"),
+		tools.WithOutputSchemaAnyOf[StartResponse, WaitResponse](),
+		withExportID(),
+		mcp.WithBoolean(toolArgWaitForCompletion,
+			mcp.Description("Optional. If true, poll the export until it has finished and return its final status "+
+				"and download URL. Defaults to false."),
+		),
+		mcp.WithNumber(toolArgMaxWaitSeconds,
+			mcp.Description(fmt.Sprintf("Optional. The maximum number of seconds to wait for the export to finish, "+
+				"when %s is true. Defaults to %d.", toolArgWaitForCompletion, int(defaultMaxWait.Seconds()))),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &startTool,
+		Handler: startHandler(portkeyCfg, toolCfg.PollInterval),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// startHandler calls the Portkey Start Log Export API, then optionally waits for the export to finish, checking on it
+// every pollInterval.
+func startHandler(portkey config.Portkey, pollInterval time.Duration) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getStartToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp StartResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, args.exportID, "start"),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if !args.waitForCompletion {
+			return tools.NewToolResultJSON(lgr, portkeyResp), nil
+		}
+
+		return waitForCompletion(ctx, portkey, request, args, pollInterval), nil
+	}
+}
+
+// waitForCompletion polls an export until it reaches a terminal status, the wait times out, or the tool call is
+// cancelled. A progress notification is sent after every poll, if the client asked for them.
+func waitForCompletion(
+	ctx context.Context,
+	portkey config.Portkey,
+	request mcp.CallToolRequest,
+	args startToolArgs,
+	pollInterval time.Duration,
+) *mcp.CallToolResult {
+	lgr := middleware.GetLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, args.maxWait)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var export Export
+
+	for polls := 1; ; polls++ {
+		current, errResult := getExport(ctx, portkey, args.exportID)
+		if errResult != nil {
+			// A poll that is cut short by the wait running out is reported as such, rather than as a Portkey error.
+			if ctx.Err() != nil {
+				return stoppedWaiting(ctx, args, export.Status)
+			}
+
+			return errResult
+		}
+
+		export = current
+
+		notifyProgress(ctx, request, polls, export.Status)
+
+		switch export.Status {
+		case StatusSuccess:
+			download, errResult := getDownload(ctx, portkey, args.exportID)
+			if errResult != nil {
+				return errResult
+			}
+
+			return tools.NewToolResultJSON(lgr, WaitResponse{Export: export, SignedURL: download.SignedURL})
+
+		case StatusFailed, StatusStopped:
+			return tools.NewToolResultJSON(lgr, WaitResponse{Export: export, SignedURL: ""})
+		}
+
+		select {
+		case <-ctx.Done():
+			return stoppedWaiting(ctx, args, export.Status)
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// stoppedWaiting returns the result for a wait that timed out or was cancelled, given the export's last known status.
+func stoppedWaiting(ctx context.Context, args startToolArgs, status string) *mcp.CallToolResult {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		middleware.GetLogger(ctx).Info("timed out waiting for log export to finish", "export_id", args.exportID)
+
+		return mcp.NewToolResultError(fmt.Sprintf("log export did not finish within %s, its last status was %q; use "+
+			"the log_export_get tool to keep checking", args.maxWait, status))
+	}
+
+	return mcp.NewToolResultError("cancelled while waiting for log export to finish")
+}
+
+// notifyProgress sends an MCP progress notification, if the client provided a progress token for this tool call.
+// The total is unknown, so progress is the number of status polls made so far.
+func notifyProgress(ctx context.Context, request mcp.CallToolRequest, polls int, status string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return
+	}
+
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return
+	}
+
+	err := mcpServer.SendNotificationToClient(ctx, methodNotificationProgress, map[string]any{
+		"progressToken": request.Params.Meta.ProgressToken,
+		"progress":      polls,
+		"message":       "log export status: " + status,
+	})
+	if err != nil {
+		middleware.GetLogger(ctx).Debug("failed to send progress notification", "error", err)
+	}
+}
+
+func getStartToolArguments(request mcp.CallToolRequest) (startToolArgs, error) {
+	exportID, err := getExportID(request)
+	if err != nil {
+		return startToolArgs{}, err
+	}
+
+	maxWait := defaultMaxWait
+
+	maxWaitSeconds := mcp.ParseInt(request, toolArgMaxWaitSeconds, 0)
+	if maxWaitSeconds != 0 {
+		maxWait = time.Duration(maxWaitSeconds) * time.Second
+		if maxWaitSeconds < 0 || maxWait > maxMaxWait {
+			return startToolArgs{}, ErrInvalidMaxWait
+		}
+	}
+
+	return startToolArgs{
+		exportID:          exportID,
+		waitForCompletion: mcp.ParseBoolean(request, toolArgWaitForCompletion, false),
+		maxWait:           maxWait,
+	}, nil
+}
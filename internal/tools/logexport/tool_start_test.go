@@ -0,0 +1,390 @@
+package logexport_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logexport"
+)
+
+const (
+	exportID    = "exp_1"
+	signedURL   = "https://example.com/exp_1.jsonl"
+	testTimeout = 10 * time.Second
+)
+
+// fakeExport serves a log export that reports each of statuses in turn when polled, and then keeps reporting the
+// last one.
+type fakeExport struct {
+	statuses []string
+
+	starts    atomic.Int32
+	polls     atomic.Int32
+	downloads atomic.Int32
+}
+
+func (f *fakeExport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/logs/exports/" + exportID + "/start":
+		f.starts.Add(1)
+		_ = json.NewEncoder(w).Encode(logexport.StartResponse{Message: "export started", Object: "export"})
+
+	case "/logs/exports/" + exportID + "/download":
+		f.downloads.Add(1)
+		_ = json.NewEncoder(w).Encode(logexport.DownloadResponse{SignedURL: signedURL})
+
+	case "/logs/exports/" + exportID:
+		polls := int(f.polls.Add(1))
+		status := f.statuses[min(polls, len(f.statuses))-1]
+		_ = json.NewEncoder(w).Encode(logexport.Export{ID: exportID, Status: status}) //nolint:exhaustruct
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// testSession is a client session that collects the notifications sent to it.
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s testSession) SessionID() string                                   { return "session" }
+
+func newStartTool(baseURL string) tools.Tuple {
+	return logexport.NewStartTool(
+		config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: baseURL}, //nolint:exhaustruct
+		config.LogExportStartTool{Description: "", Enabled: true, PollInterval: 10 * time.Millisecond},
+	)
+}
+
+func callStart(t *testing.T, baseURL string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+	defer cancel()
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+
+	result, err := newStartTool(baseURL).Handler(ctx, request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	return result
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+
+	return ""
+}
+
+func TestStartWithoutWaiting(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeExport{statuses: []string{logexport.StatusInProgress}} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	result := callStart(t, srv.URL, map[string]any{"export_id": exportID})
+
+	if _, ok := result.StructuredContent.(logexport.StartResponse); !ok || result.IsError {
+		t.Fatalf("expected a start response, got %#v", result)
+	}
+
+	if fake.starts.Load() != 1 || fake.polls.Load() != 0 {
+		t.Errorf("starts = %d, polls = %d, want 1 start and no polls", fake.starts.Load(), fake.polls.Load())
+	}
+}
+
+func TestStartWaitsForCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statuses      []string
+		wantStatus    string
+		wantPolls     int32
+		wantSignedURL string
+		wantDownloads int32
+	}{
+		{
+			name: "succeeds",
+			statuses: []string{
+				logexport.StatusInProgress, logexport.StatusInProgress, logexport.StatusSuccess,
+			},
+			wantStatus:    logexport.StatusSuccess,
+			wantPolls:     3,
+			wantSignedURL: signedURL,
+			wantDownloads: 1,
+		},
+		{
+			name:          "fails",
+			statuses:      []string{logexport.StatusDraft, logexport.StatusInProgress, logexport.StatusFailed},
+			wantStatus:    logexport.StatusFailed,
+			wantPolls:     3,
+			wantSignedURL: "",
+			wantDownloads: 0,
+		},
+		{
+			name:          "is stopped",
+			statuses:      []string{logexport.StatusStopped},
+			wantStatus:    logexport.StatusStopped,
+			wantPolls:     1,
+			wantSignedURL: "",
+			wantDownloads: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeExport{statuses: tt.statuses} //nolint:exhaustruct
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+
+			result := callStart(t, srv.URL, map[string]any{"export_id": exportID, "wait_for_completion": true})
+
+			resp, ok := result.StructuredContent.(logexport.WaitResponse)
+			if !ok || result.IsError {
+				t.Fatalf("expected a wait response, got %#v", result)
+			}
+
+			if resp.Export.Status != tt.wantStatus || resp.SignedURL != tt.wantSignedURL {
+				t.Errorf("status = %q, signed url = %q, want %q and %q",
+					resp.Export.Status, resp.SignedURL, tt.wantStatus, tt.wantSignedURL)
+			}
+
+			if fake.polls.Load() != tt.wantPolls {
+				t.Errorf("polls = %d, want %d", fake.polls.Load(), tt.wantPolls)
+			}
+
+			if fake.downloads.Load() != tt.wantDownloads {
+				t.Errorf("downloads = %d, want %d", fake.downloads.Load(), tt.wantDownloads)
+			}
+		})
+	}
+}
+
+func TestStartMaxWait(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		maxWaitSeconds float64
+		wantErr        bool
+	}{
+		{name: "default", maxWaitSeconds: 0, wantErr: false},
+		{name: "longest", maxWaitSeconds: 1800, wantErr: false},
+		{name: "negative", maxWaitSeconds: -1, wantErr: true},
+		{name: "above the cap", maxWaitSeconds: 1801, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeExport{statuses: []string{logexport.StatusSuccess}} //nolint:exhaustruct
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+
+			result := callStart(t, srv.URL, map[string]any{
+				"export_id":           exportID,
+				"wait_for_completion": true,
+				"max_wait_seconds":    tt.maxWaitSeconds,
+			})
+
+			if result.IsError != tt.wantErr {
+				t.Fatalf("error = %v, want %v: %s", result.IsError, tt.wantErr, resultText(result))
+			}
+
+			if tt.wantErr && (!strings.Contains(resultText(result), logexport.ErrInvalidMaxWait.Error()) ||
+				fake.starts.Load() != 0) {
+				t.Errorf("expected the export not to be started, got %d starts: %s",
+					fake.starts.Load(), resultText(result))
+			}
+		})
+	}
+}
+
+// TestStartTimesOut makes sure that waiting stops once max_wait_seconds is up, with the export's last status.
+func TestStartTimesOut(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeExport{statuses: []string{logexport.StatusInProgress}} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	start := time.Now()
+	result := callStart(t, srv.URL, map[string]any{
+		"export_id":           exportID,
+		"wait_for_completion": true,
+		"max_wait_seconds":    1,
+	})
+
+	wantText := `did not finish within 1s, its last status was "in_progress"`
+	if !result.IsError || !strings.Contains(resultText(result), wantText) {
+		t.Fatalf("expected a timeout error, got %#v", result)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second || elapsed > 5*time.Second {
+		t.Errorf("gave up after %s, want about 1s", elapsed)
+	}
+
+	if fake.polls.Load() < 2 {
+		t.Errorf("polls = %d, want the export to be polled until the timeout", fake.polls.Load())
+	}
+}
+
+// serveStart registers the start tool with an MCP server, and returns a context for a session whose notifications are
+// collected.
+func serveStart(t *testing.T, baseURL string) (*server.MCPServer, context.Context, chan mcp.JSONRPCNotification) {
+	t.Helper()
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0")
+
+	tool := newStartTool(baseURL)
+	mcpServer.AddTool(*tool.Tool, tool.Handler)
+
+	notifications := make(chan mcp.JSONRPCNotification, 100) //nolint:mnd
+
+	return mcpServer, mcpServer.WithContext(t.Context(), testSession{notifications: notifications}), notifications
+}
+
+func callStartMessage(ctx context.Context, mcpServer *server.MCPServer, meta string) mcp.JSONRPCMessage {
+	return mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{`+
+		`"name":"log_export_start","arguments":{"export_id":"`+exportID+`","wait_for_completion":true}`+meta+`}}`))
+}
+
+func TestStartReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeExport{ //nolint:exhaustruct
+		statuses: []string{logexport.StatusInProgress, logexport.StatusInProgress, logexport.StatusSuccess},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	mcpServer, ctx, notifications := serveStart(t, srv.URL)
+
+	resp, ok := callStartMessage(ctx, mcpServer, `,"_meta":{"progressToken":"export-progress"}`).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a result, got %#v", resp)
+	}
+
+	close(notifications)
+
+	wantMessages := []string{
+		"log export status: in_progress", "log export status: in_progress", "log export status: success",
+	}
+
+	var progress int
+
+	for notification := range notifications {
+		fields := notification.Params.AdditionalFields
+
+		if notification.Method != "notifications/progress" || fields["progressToken"] != "export-progress" ||
+			progress >= len(wantMessages) || fields["message"] != wantMessages[progress] ||
+			fields["progress"] != progress+1 {
+			t.Errorf("unexpected notification %d: %s %v", progress+1, notification.Method, fields)
+		}
+
+		progress++
+	}
+
+	if progress != len(wantMessages) {
+		t.Errorf("got %d progress notifications, want %d", progress, len(wantMessages))
+	}
+}
+
+func TestStartWithoutProgressToken(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeExport{statuses: []string{logexport.StatusInProgress, logexport.StatusSuccess}} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	mcpServer, ctx, notifications := serveStart(t, srv.URL)
+
+	if _, ok := callStartMessage(ctx, mcpServer, "").(mcp.JSONRPCResponse); !ok {
+		t.Fatal("expected a result")
+	}
+
+	if len(notifications) != 0 {
+		t.Errorf("got %d notifications without a progress token, want none", len(notifications))
+	}
+}
+
+// TestStartCancelled makes sure that waiting stops as soon as the tool call is cancelled.
+func TestStartCancelled(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeExport{statuses: []string{logexport.StatusInProgress}} //nolint:exhaustruct
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	mcpServer := server.NewMCPServer("test", "v1.0.0")
+
+	tool := logexport.NewStartTool(
+		config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: srv.URL}, //nolint:exhaustruct
+		config.LogExportStartTool{Description: "", Enabled: true, PollInterval: time.Hour},
+	)
+	mcpServer.AddTool(*tool.Tool, tool.Handler)
+
+	notifications := make(chan mcp.JSONRPCNotification, 1)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	ctx = mcpServer.WithContext(ctx, testSession{notifications: notifications})
+
+	// The first poll's progress notification is sent once the export has been polled, and before waiting for the
+	// next poll, which won't come for an hour.
+	go func() {
+		<-notifications
+		cancel()
+	}()
+
+	done := make(chan mcp.JSONRPCMessage)
+
+	go func() {
+		done <- callStartMessage(ctx, mcpServer, `,"_meta":{"progressToken":"export-progress"}`)
+	}()
+
+	select {
+	case resp := <-done:
+		jsonrpcResp, _ := resp.(mcp.JSONRPCResponse)
+
+		result, _ := jsonrpcResp.Result.(*mcp.CallToolResult)
+		if result == nil || !result.IsError || !strings.Contains(resultText(result), "cancelled while waiting") {
+			t.Errorf("expected a cancellation error, got %#v", resp)
+		}
+
+	case <-time.After(testTimeout):
+		t.Fatal("waiting for the export wasn't cancelled")
+	}
+
+	if fake.polls.Load() != 1 {
+		t.Errorf("polls = %d, want 1", fake.polls.Load())
+	}
+}
@@ -0,0 +1,22 @@
+package logssearch
+
+import "time"
+
+// Request represents the query parameters for the Portkey Logs API.
+type Request struct {
+	// Optional arguments
+	TimeOfGenerationMin *time.Time        `json:"time_of_generation_min,omitempty"`
+	TimeOfGenerationMax *time.Time        `json:"time_of_generation_max,omitempty"`
+	TraceID             string            `json:"trace_id,omitempty"`
+	SpanID              string            `json:"span_id,omitempty"`
+	StatusCode          string            `json:"status_code,omitempty"`
+	AIModel             string            `json:"ai_model,omitempty"`
+	VirtualKeys         string            `json:"virtual_keys,omitempty"`
+	Configs             string            `json:"configs,omitempty"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+	CostMin             *float64          `json:"cost_min,omitempty"`
+	CostMax             *float64          `json:"cost_max,omitempty"`
+	WorkspaceID         string            `json:"workspace_id,omitempty"`
+	CurrentPage         *int              `json:"current_page,omitempty"`
+	PageSize            *int              `json:"page_size,omitempty"`
+}
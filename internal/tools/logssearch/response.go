@@ -0,0 +1,39 @@
+package logssearch
+
+import "time"
+
+// Response represents the full response structure from the Portkey Logs API.
+type Response struct {
+	Data  []LogEntry `json:"data"`
+	Total int        `json:"total"`
+}
+
+// LogEntry represents a single gateway request log in the Logs API response.
+type LogEntry struct {
+	ID               string         `json:"id"`
+	TraceID          string         `json:"trace_id,omitempty"`
+	SpanID           string         `json:"span_id,omitempty"`
+	ParentSpanID     string         `json:"parent_span_id,omitempty"`
+	SpanName         string         `json:"span_name,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	StatusCode       int            `json:"status_code"`
+	AIProvider       string         `json:"ai_provider,omitempty"`
+	AIModel          string         `json:"ai_model,omitempty"`
+	VirtualKey       string         `json:"virtual_key,omitempty"`
+	Config           string         `json:"config,omitempty"`
+	PromptSlug       string         `json:"prompt_slug,omitempty"`
+	PromptTokens     int            `json:"prompt_tokens"`
+	CompletionTokens int            `json:"completion_tokens"`
+	TotalTokens      int            `json:"total_tokens"`
+	Cost             float64        `json:"cost"`
+	CostCurrency     string         `json:"cost_currency,omitempty"`
+	ResponseTimeMS   int64          `json:"response_time"`
+	CacheStatus      string         `json:"cache_status,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+}
+
+// ProjectedResponse is returned instead of Response when the caller asks for a subset of log fields.
+type ProjectedResponse struct {
+	Data  []map[string]any `json:"data"`
+	Total int              `json:"total"`
+}
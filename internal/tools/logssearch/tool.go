@@ -0,0 +1,399 @@
+package logssearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	toolName = "logs_search"
+
+	// Tool arguments.
+	toolArgTimeOfGenerationMin = "time_of_generation_min"
+	toolArgTimeOfGenerationMax = "time_of_generation_max"
+	toolArgTraceID             = "trace_id"
+	toolArgSpanID              = "span_id"
+	toolArgStatusCode          = "status_code"
+	toolArgAIModel             = "ai_model"
+	toolArgVirtualKeys         = "virtual_keys"
+	toolArgConfigs             = "configs"
+	toolArgMetadata            = "metadata"
+	toolArgCostMin             = "cost_min"
+	toolArgCostMax             = "cost_max"
+	toolArgWorkspaceID         = "workspace_id"
+	toolArgCurrentPage         = "current_page"
+	toolArgPageSize            = "page_size"
+	toolArgFields              = "fields"
+
+	// Portkey API query parameters.
+	apiParamTimeOfGenerationMin = "time_of_generation_min"
+	apiParamTimeOfGenerationMax = "time_of_generation_max"
+	apiParamTraceID             = "trace_id"
+	apiParamSpanID              = "span_id"
+	apiParamStatusCode          = "status_code"
+	apiParamAIModel             = "ai_model"
+	apiParamVirtualKeys         = "virtual_keys"
+	apiParamConfigs             = "configs"
+	apiParamMetadata            = "metadata"
+	apiParamCostMin             = "cost_min"
+	apiParamCostMax             = "cost_max"
+	apiParamWorkspaceID         = "workspace_id"
+	apiParamCurrentPage         = "current_page"
+	apiParamPageSize            = "page_size"
+
+	errTextInternalError = "internal error while processing request"
+)
+
+var (
+	ErrInvalidPageSize    = fmt.Errorf("%s must be a positive integer", toolArgPageSize)
+	ErrInvalidCurrentPage = fmt.Errorf("%s must be a positive integer", toolArgCurrentPage)
+	ErrInvalidTimeRange   = fmt.Errorf("%s must not be after %s",
+		toolArgTimeOfGenerationMin, toolArgTimeOfGenerationMax)
+	ErrInvalidCostRange       = fmt.Errorf("%s must not be greater than %s", toolArgCostMin, toolArgCostMax)
+	ErrUnknownField           = errors.New("unknown log field")
+	errFieldProjectionFailure = errors.New("failed to project log fields")
+)
+
+type toolArgs struct {
+	request Request
+	fields  []string
+}
+
+func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Search the gateway request logs in your Portkey account. Use this tool to look up production " +
+		"calls that misbehaved, filtering by time range, trace ID, status code, model, virtual key, config, metadata " +
+		"and cost. Results are paginated; request only the fields you need to keep the output small."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	logsSearchTool := mcp.NewTool(
+		toolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Search Logs"),
+		tools.WithOutputSchemaAnyOf[Response, ProjectedResponse](),
+		mcp.WithString(toolArgTimeOfGenerationMin,
+			mcp.Description("Optional. Only return logs generated at or after this time, in RFC 3339 format "+
+				"(e.g. '2025-01-02T15:04:05Z')."),
+		),
+		mcp.WithString(toolArgTimeOfGenerationMax,
+			mcp.Description("Optional. Only return logs generated at or before this time, in RFC 3339 format."),
+		),
+		mcp.WithString(toolArgTraceID,
+			mcp.Description("Optional. Filter logs by trace ID."),
+		),
+		mcp.WithString(toolArgSpanID,
+			mcp.Description("Optional. Filter logs by span ID."),
+		),
+		mcp.WithString(toolArgStatusCode,
+			mcp.Description("Optional. Filter logs by HTTP status code. Multiple codes may be comma-separated "+
+				"(e.g. '429,500')."),
+		),
+		mcp.WithString(toolArgAIModel,
+			mcp.Description("Optional. Filter logs by model (e.g. 'gpt-4o')."),
+		),
+		mcp.WithString(toolArgVirtualKeys,
+			mcp.Description("Optional. Filter logs by virtual key slug. Multiple slugs may be comma-separated."),
+		),
+		mcp.WithString(toolArgConfigs,
+			mcp.Description("Optional. Filter logs by config slug. Multiple slugs may be comma-separated."),
+		),
+		mcp.WithObject(toolArgMetadata,
+			mcp.Description("Optional. Filter logs by metadata. The object should be a JSON object with key-value pairs "+
+				"of string metadata keys to string values."),
+		),
+		mcp.WithNumber(toolArgCostMin,
+			mcp.Description("Optional. Only return logs that cost at least this much, in cents."),
+		),
+		mcp.WithNumber(toolArgCostMax,
+			mcp.Description("Optional. Only return logs that cost at most this much, in cents."),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Filter logs by workspace ID."),
+		),
+		mcp.WithNumber(toolArgCurrentPage,
+			mcp.Description("Optional. Page number for pagination. Starts at 1."),
+		),
+		mcp.WithNumber(toolArgPageSize,
+			mcp.Description("Optional. Number of results per page."),
+		),
+		mcp.WithArray(toolArgFields,
+			mcp.Description(fmt.Sprintf("Optional. Only include these fields in each log entry. Available fields: %s.",
+				strings.Join(logEntryFields(), ", "))),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &logsSearchTool,
+		Handler: logsSearchHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// logsSearchHandler calls the Portkey Logs API and returns the typed result.
+func logsSearchHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		apiURL, err := CreateURL(portkey, args.request)
+		if err != nil {
+			lgr.Error("failed to create url", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError), nil
+		}
+
+		var portkeyResp Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    apiURL,
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if len(args.fields) == 0 {
+			return tools.NewToolResultJSON(lgr, portkeyResp), nil
+		}
+
+		projected, err := project(portkeyResp, args.fields)
+		if err != nil {
+			lgr.Error("failed to project log fields", "error", err)
+
+			return mcp.NewToolResultError(errTextInternalError), nil
+		}
+
+		return tools.NewToolResultJSON(lgr, projected), nil
+	}
+}
+
+func getToolArguments(request mcp.CallToolRequest) (toolArgs, error) {
+	//nolint:exhaustruct
+	req := Request{
+		TraceID:     mcp.ParseString(request, toolArgTraceID, ""),
+		SpanID:      mcp.ParseString(request, toolArgSpanID, ""),
+		StatusCode:  mcp.ParseString(request, toolArgStatusCode, ""),
+		AIModel:     mcp.ParseString(request, toolArgAIModel, ""),
+		VirtualKeys: mcp.ParseString(request, toolArgVirtualKeys, ""),
+		Configs:     mcp.ParseString(request, toolArgConfigs, ""),
+		WorkspaceID: mcp.ParseString(request, toolArgWorkspaceID, ""),
+	}
+
+	var err error
+
+	if req.TimeOfGenerationMin, err = tools.ParseTime(request, toolArgTimeOfGenerationMin); err != nil {
+		return toolArgs{}, err
+	}
+
+	if req.TimeOfGenerationMax, err = tools.ParseTime(request, toolArgTimeOfGenerationMax); err != nil {
+		return toolArgs{}, err
+	}
+
+	if req.TimeOfGenerationMin != nil && req.TimeOfGenerationMax != nil &&
+		req.TimeOfGenerationMin.After(*req.TimeOfGenerationMax) {
+		return toolArgs{}, ErrInvalidTimeRange
+	}
+
+	if req.CostMin, err = tools.ParseNonNegativeFloat(request, toolArgCostMin); err != nil {
+		return toolArgs{}, err
+	}
+
+	if req.CostMax, err = tools.ParseNonNegativeFloat(request, toolArgCostMax); err != nil {
+		return toolArgs{}, err
+	}
+
+	if req.CostMin != nil && req.CostMax != nil && *req.CostMin > *req.CostMax {
+		return toolArgs{}, ErrInvalidCostRange
+	}
+
+	if req.Metadata, err = tools.ParseStringMap(request, toolArgMetadata); err != nil {
+		return toolArgs{}, err
+	}
+
+	// Handle optional integer arguments.
+	currentPage := mcp.ParseInt(request, toolArgCurrentPage, 0)
+	if currentPage > 0 {
+		req.CurrentPage = &currentPage
+	} else if currentPage < 0 {
+		return toolArgs{}, ErrInvalidCurrentPage
+	}
+
+	pageSize := mcp.ParseInt(request, toolArgPageSize, 0)
+	if pageSize > 0 {
+		req.PageSize = &pageSize
+	} else if pageSize < 0 {
+		return toolArgs{}, ErrInvalidPageSize
+	}
+
+	fields, err := getFields(request)
+	if err != nil {
+		return toolArgs{}, err
+	}
+
+	return toolArgs{
+		request: req,
+		fields:  fields,
+	}, nil
+}
+
+func getFields(request mcp.CallToolRequest) ([]string, error) {
+	fields, err := tools.ParseStringSlice(request, toolArgFields)
+	if err != nil {
+		return nil, err
+	}
+
+	known := logEntryFields()
+
+	for _, field := range fields {
+		if !slices.Contains(known, field) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// CreateURL builds the Portkey Logs API URL for the provided filters.
+func CreateURL(portkey config.Portkey, req Request) (string, error) {
+	baseURL := portkey.BaseURL + "/logs"
+
+	// Add query parameters.
+	values := url.Values{}
+	if req.TimeOfGenerationMin != nil {
+		values.Add(apiParamTimeOfGenerationMin, req.TimeOfGenerationMin.Format(time.RFC3339))
+	}
+
+	if req.TimeOfGenerationMax != nil {
+		values.Add(apiParamTimeOfGenerationMax, req.TimeOfGenerationMax.Format(time.RFC3339))
+	}
+
+	if req.TraceID != "" {
+		values.Add(apiParamTraceID, req.TraceID)
+	}
+
+	if req.SpanID != "" {
+		values.Add(apiParamSpanID, req.SpanID)
+	}
+
+	if req.StatusCode != "" {
+		values.Add(apiParamStatusCode, req.StatusCode)
+	}
+
+	if req.AIModel != "" {
+		values.Add(apiParamAIModel, req.AIModel)
+	}
+
+	if req.VirtualKeys != "" {
+		values.Add(apiParamVirtualKeys, req.VirtualKeys)
+	}
+
+	if req.Configs != "" {
+		values.Add(apiParamConfigs, req.Configs)
+	}
+
+	if len(req.Metadata) > 0 {
+		metadata, err := json.Marshal(req.Metadata)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal metadata filter: %w", err)
+		}
+
+		values.Add(apiParamMetadata, string(metadata))
+	}
+
+	if req.CostMin != nil {
+		values.Add(apiParamCostMin, strconv.FormatFloat(*req.CostMin, 'f', -1, 64))
+	}
+
+	if req.CostMax != nil {
+		values.Add(apiParamCostMax, strconv.FormatFloat(*req.CostMax, 'f', -1, 64))
+	}
+
+	if req.WorkspaceID != "" {
+		values.Add(apiParamWorkspaceID, req.WorkspaceID)
+	}
+
+	if req.CurrentPage != nil {
+		values.Add(apiParamCurrentPage, strconv.Itoa(*req.CurrentPage))
+	}
+
+	if req.PageSize != nil {
+		values.Add(apiParamPageSize, strconv.Itoa(*req.PageSize))
+	}
+
+	if len(values) > 0 {
+		return baseURL + "?" + values.Encode(), nil
+	}
+
+	return baseURL, nil
+}
+
+// logEntryFields returns the JSON field names of LogEntry, which are the fields available for projection.
+func logEntryFields() []string {
+	entryType := reflect.TypeFor[LogEntry]()
+	fields := make([]string, 0, entryType.NumField())
+
+	for i := range entryType.NumField() {
+		name, _, _ := strings.Cut(entryType.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+
+	return fields
+}
+
+// project reduces each log entry to the requested fields. Fields that were omitted from an entry are left out.
+func project(resp Response, fields []string) (ProjectedResponse, error) {
+	projected := ProjectedResponse{
+		Data:  make([]map[string]any, 0, len(resp.Data)),
+		Total: resp.Total,
+	}
+
+	for _, entry := range resp.Data {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return ProjectedResponse{}, fmt.Errorf("%w: %w", errFieldProjectionFailure, err)
+		}
+
+		var all map[string]any
+		if err := json.Unmarshal(data, &all); err != nil {
+			return ProjectedResponse{}, fmt.Errorf("%w: %w", errFieldProjectionFailure, err)
+		}
+
+		subset := make(map[string]any, len(fields))
+
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				subset[field] = value
+			}
+		}
+
+		projected.Data = append(projected.Data, subset)
+	}
+
+	return projected, nil
+}
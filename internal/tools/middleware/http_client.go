@@ -27,13 +27,17 @@ func DefaultHTTPClient() *http.Client {
 func WithHTTPClient(client *http.Client) Middleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			ctx = context.WithValue(ctx, clientKey, client)
-
-			return next(ctx, request)
+			return next(ContextWithHTTPClient(ctx, client), request)
 		}
 	}
 }
 
+// ContextWithHTTPClient returns a copy of the context carrying the provided HTTP client. It is used by handlers that
+// aren't wrapped in tool middleware, like prompt and resource handlers.
+func ContextWithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, clientKey, client)
+}
+
 // GetHTTPClient retrieves the HTTP client from the context.
 // If no client is found in the context, a default HTTP client is created and returned.
 func GetHTTPClient(ctx context.Context) *http.Client {
@@ -21,7 +21,13 @@ func GetLogger(ctx context.Context) *slog.Logger {
 	return slog.Default()
 }
 
-// WithHTTPRequestLogging adds request-scoped logging context for HTTP (SSE transport) requests.
+// ContextWithLogger returns a copy of the context carrying the provided request-scoped logger.
+func ContextWithLogger(ctx context.Context, lgr *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, lgr)
+}
+
+// WithHTTPRequestLogging adds request-scoped logging context for HTTP (SSE and streamable HTTP transport) requests.
+// The authenticated principal, and the subject of the client's TLS certificate, are logged for auditing.
 func WithHTTPRequestLogging(ctx context.Context, r *http.Request) context.Context {
 	reqLogger := GetLogger(ctx).With(
 		"http_req_method", r.Method,
@@ -29,6 +35,14 @@ func WithHTTPRequestLogging(ctx context.Context, r *http.Request) context.Contex
 		"http_req_remote_addr", r.RemoteAddr,
 	)
 
+	if principal, ok := GetPrincipal(ctx); ok {
+		reqLogger = reqLogger.With("principal", principal)
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		reqLogger = reqLogger.With("tls_client_subject", r.TLS.PeerCertificates[0].Subject.String())
+	}
+
 	reqLogger.Debug("processing http request")
 
 	return context.WithValue(ctx, loggerKey, reqLogger)
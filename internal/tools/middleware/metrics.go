@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+)
+
+// WithToolCallMetrics records the count, errors and latency of MCP tool call requests.
+func WithToolCallMetrics(m *metrics.Metrics) Middleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+
+			result, err := next(ctx, req)
+
+			m.ObserveToolCall(req.Params.Name, time.Since(start), result != nil && result.IsError, err)
+
+			return result, err
+		}
+	}
+}
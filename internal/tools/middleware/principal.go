@@ -0,0 +1,33 @@
+package middleware
+
+import "context"
+
+const principalKey = ctxKey("principal")
+
+// ContextWithPrincipal returns a copy of the context carrying the name of the authenticated principal.
+func ContextWithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// GetPrincipal retrieves the name of the authenticated principal from the context. It returns false for requests that
+// weren't authenticated, like those over stdio.
+func GetPrincipal(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey).(string)
+
+	return principal, ok
+}
+
+const scopesKey = ctxKey("scopes")
+
+// ContextWithScopes returns a copy of the context carrying the scopes granted to the request's OAuth access token.
+func ContextWithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// GetScopes retrieves the scopes granted to the request's OAuth access token from the context. It returns false for
+// requests that weren't authenticated with an access token, which aren't limited by scope.
+func GetScopes(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey).([]string)
+
+	return scopes, ok
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tracing"
+)
+
+// WithToolCallTracing records a span for each MCP tool call request, which is the parent of the spans of the Portkey
+// requests that the call makes. The span continues the client's trace when the request's _meta, or the HTTP request
+// that carried it, has a traceparent. The trace ID is added to the request-scoped logger.
+func WithToolCallTracing(tracer *tracing.Tracer) Middleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req.Params.Meta != nil {
+				if traceparent, ok := req.Params.Meta.AdditionalFields[tracing.TraceparentHeader].(string); ok {
+					if parent, ok := tracing.ParseTraceparent(traceparent); ok {
+						ctx = tracing.ContextWithRemoteParent(ctx, parent)
+					}
+				}
+			}
+
+			ctx, span := tracer.Start(ctx, "tools/call "+req.Params.Name, tracing.SpanKindServer)
+			defer span.End()
+
+			ctx = ContextWithLogger(ctx, GetLogger(ctx).With("trace_id", span.SpanContext().TraceID.String()))
+
+			arguments, _ := json.Marshal(req.Params.Arguments)
+
+			span.SetAttributes(
+				tracing.String("mcp.method.name", string(mcp.MethodToolsCall)),
+				tracing.String("gen_ai.tool.name", req.Params.Name),
+				tracing.Int("mcp.tool.arguments.size", len(arguments)),
+			)
+
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				span.SetAttributes(tracing.String("mcp.session.id", session.SessionID()))
+			}
+
+			result, err := next(ctx, req)
+
+			isError := result != nil && result.IsError
+			span.SetAttributes(tracing.Bool("mcp.tool.result.is_error", isError))
+
+			switch {
+			case err != nil:
+				span.SetError(err.Error())
+			case isError:
+				span.SetError("tool returned an error result")
+			}
+
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package policies
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Consumption levels, from least to most severe.
+const (
+	LevelOK       = "ok"
+	LevelWarning  = "warning"
+	LevelExceeded = "exceeded"
+)
+
+const percent = 100
+
+// MeasureConsumption reports the usage of every group of every policy against its limit. A group is at the warning
+// level once it reaches either the policy's alert threshold or warnAtPercent of its limit. The most severe entries
+// come first.
+func MeasureConsumption(limits []UsageLimit, warnAtPercent float64) []Consumption {
+	var consumption []Consumption
+
+	for _, limit := range limits {
+		valueKeys := limit.ValueKeys
+		if len(valueKeys) == 0 {
+			// A policy that hasn't been used yet has no groups, but is still worth reporting.
+			valueKeys = []ValueKeyUsage{{ValueKey: "", CurrentUsage: 0, Status: "", LastResetAt: nil}}
+		}
+
+		for _, valueKey := range valueKeys {
+			consumption = append(consumption, measure(limit, valueKey, warnAtPercent))
+		}
+	}
+
+	slices.SortStableFunc(consumption, func(a, b Consumption) int {
+		if c := cmp.Compare(severity(b.Level), severity(a.Level)); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(b.PercentUsed, a.PercentUsed)
+	})
+
+	return consumption
+}
+
+func measure(limit UsageLimit, valueKey ValueKeyUsage, warnAtPercent float64) Consumption {
+	var percentUsed float64
+	if limit.CreditLimit > 0 {
+		percentUsed = valueKey.CurrentUsage / limit.CreditLimit * percent
+	}
+
+	level := LevelOK
+
+	switch {
+	case limit.CreditLimit > 0 && valueKey.CurrentUsage >= limit.CreditLimit:
+		level = LevelExceeded
+	case percentUsed >= warnAtPercent,
+		limit.AlertThreshold != nil && valueKey.CurrentUsage >= *limit.AlertThreshold:
+		level = LevelWarning
+	}
+
+	return Consumption{
+		PolicyID:       limit.ID,
+		PolicyName:     limit.Name,
+		Type:           limit.Type,
+		ValueKey:       valueKey.ValueKey,
+		CurrentUsage:   valueKey.CurrentUsage,
+		CreditLimit:    limit.CreditLimit,
+		AlertThreshold: limit.AlertThreshold,
+		PercentUsed:    percentUsed,
+		Remaining:      max(limit.CreditLimit-valueKey.CurrentUsage, 0),
+		PeriodicReset:  limit.PeriodicReset,
+		Level:          level,
+	}
+}
+
+func severity(level string) int {
+	switch level {
+	case LevelExceeded:
+		return 2 //nolint:mnd
+	case LevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,53 @@
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/policies"
+)
+
+func TestMeasureConsumption(t *testing.T) {
+	t.Parallel()
+
+	threshold := 50.0
+
+	//nolint:exhaustruct
+	limits := []policies.UsageLimit{
+		{
+			ID:             "pol_cost",
+			CreditLimit:    100,
+			AlertThreshold: &threshold,
+			ValueKeys: []policies.ValueKeyUsage{
+				{ValueKey: "key_a", CurrentUsage: 10},
+				{ValueKey: "key_b", CurrentUsage: 60},
+				{ValueKey: "key_c", CurrentUsage: 120},
+			},
+		},
+		{ID: "pol_unused", CreditLimit: 1000},
+	}
+
+	consumption := policies.MeasureConsumption(limits, 80)
+	if len(consumption) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(consumption), consumption)
+	}
+
+	want := []struct {
+		valueKey string
+		level    string
+	}{
+		{"key_c", policies.LevelExceeded},
+		{"key_b", policies.LevelWarning},
+		{"key_a", policies.LevelOK},
+		{"", policies.LevelOK},
+	}
+
+	for i, w := range want {
+		if consumption[i].ValueKey != w.valueKey || consumption[i].Level != w.level {
+			t.Errorf("entry %d: expected %s at %s, got %+v", i, w.valueKey, w.level, consumption[i])
+		}
+	}
+
+	if consumption[0].Remaining != 0 || consumption[1].PercentUsed != 60 {
+		t.Errorf("unexpected remaining or percent used: %+v", consumption[:2])
+	}
+}
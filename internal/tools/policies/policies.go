@@ -0,0 +1,300 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	// Tool arguments shared by several policy tools.
+	toolArgPolicyID    = "policy_id"
+	toolArgName        = "name"
+	toolArgConditions  = "conditions"
+	toolArgGroupBy     = "group_by"
+	toolArgType        = "type"
+	toolArgWorkspaceID = "workspace_id"
+	toolArgStatus      = "status"
+	toolArgCurrentPage = "current_page"
+	toolArgPageSize    = "page_size"
+
+	// Portkey API query parameters.
+	apiParamWorkspaceID = "workspace_id"
+	apiParamStatus      = "status"
+	apiParamType        = "type"
+	apiParamCurrentPage = "current_page"
+	apiParamPageSize    = "page_size"
+
+	statusActive = "active"
+
+	// Portkey API paths.
+	usageLimitsPath = "/policies/usage-limits"
+	rateLimitsPath  = "/policies/rate-limits"
+)
+
+var (
+	ErrPolicyIDRequired    = errors.New("policy_id is required")
+	ErrNameRequired        = errors.New("name is required")
+	ErrTypeRequired        = errors.New("type is required")
+	ErrConditionsRequired  = errors.New("at least one condition is required")
+	ErrGroupByRequired     = errors.New("at least one group_by key is required")
+	ErrConditionIncomplete = errors.New("every condition must have a key and a value")
+	ErrGroupByIncomplete   = errors.New("every group_by entry must have a key")
+	ErrInvalidStatus       = fmt.Errorf("%s must be one of: %s", toolArgStatus, strings.Join(policyStatuses, ", "))
+	ErrInvalidPageSize     = fmt.Errorf("%s must be a positive integer", toolArgPageSize)
+	ErrInvalidCurrentPage  = fmt.Errorf("%s must be a positive integer", toolArgCurrentPage)
+)
+
+//nolint:gochecknoglobals
+var (
+	policyStatuses = []string{statusActive, "archived"}
+
+	conditionSchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"key": map[string]any{
+				"type":        "string",
+				"description": "What to match on, e.g. 'api_key', 'workspace_id' or 'metadata.<key>'.",
+			},
+			"value": map[string]any{"type": "string"},
+		},
+		"required": []string{"key", "value"},
+	}
+
+	groupBySchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"key": map[string]any{
+				"type":        "string",
+				"description": "What to track the limit per, e.g. 'api_key', 'workspace_id' or 'metadata.<key>'.",
+			},
+		},
+		"required": []string{"key"},
+	}
+)
+
+type listToolArgs struct {
+	workspaceID string
+	status      string
+	policyType  string
+	currentPage *int
+	pageSize    *int
+}
+
+func withPolicyID(kind string) mcp.ToolOption {
+	return mcp.WithString(toolArgPolicyID,
+		mcp.Required(),
+		mcp.Description(fmt.Sprintf("The ID of the %s policy.", kind)),
+	)
+}
+
+func withConditions() mcp.ToolOption {
+	return mcp.WithArray(toolArgConditions,
+		mcp.Required(),
+		mcp.Description("The requests the policy applies to. All conditions must match."),
+		mcp.Items(conditionSchema),
+	)
+}
+
+func withGroupBy() mcp.ToolOption {
+	return mcp.WithArray(toolArgGroupBy,
+		mcp.Required(),
+		mcp.Description("The keys to track the limit separately for, e.g. [{\"key\": \"api_key\"}] to give every "+
+			"API key its own limit."),
+		mcp.Items(groupBySchema),
+	)
+}
+
+// withListArguments adds the filter and pagination arguments shared by the list tools.
+func withListArguments(types []string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Filter policies by workspace ID."),
+		),
+		mcp.WithString(toolArgStatus,
+			mcp.Description("Optional. Filter policies by status."),
+			mcp.Enum(policyStatuses...),
+		),
+		mcp.WithString(toolArgType,
+			mcp.Description("Optional. Filter policies by what they limit."),
+			mcp.Enum(types...),
+		),
+		mcp.WithNumber(toolArgCurrentPage,
+			mcp.Description("Optional. Page number for pagination. Starts at 1."),
+		),
+		mcp.WithNumber(toolArgPageSize,
+			mcp.Description("Optional. Number of results per page."),
+		),
+	}
+}
+
+func getPolicyID(request mcp.CallToolRequest) (string, error) {
+	policyID := mcp.ParseString(request, toolArgPolicyID, "")
+	if policyID == "" {
+		return "", ErrPolicyIDRequired
+	}
+
+	return policyID, nil
+}
+
+func getConditions(request mcp.CallToolRequest) ([]Condition, error) {
+	var conditions []Condition
+	if _, err := tools.DecodeArgument(request, toolArgConditions, &conditions); err != nil {
+		return nil, err
+	}
+
+	if len(conditions) == 0 {
+		return nil, ErrConditionsRequired
+	}
+
+	for _, condition := range conditions {
+		if condition.Key == "" || condition.Value == "" {
+			return nil, ErrConditionIncomplete
+		}
+	}
+
+	return conditions, nil
+}
+
+func getGroupBy(request mcp.CallToolRequest) ([]GroupBy, error) {
+	var groupBy []GroupBy
+	if _, err := tools.DecodeArgument(request, toolArgGroupBy, &groupBy); err != nil {
+		return nil, err
+	}
+
+	if len(groupBy) == 0 {
+		return nil, ErrGroupByRequired
+	}
+
+	for _, group := range groupBy {
+		if group.Key == "" {
+			return nil, ErrGroupByIncomplete
+		}
+	}
+
+	return groupBy, nil
+}
+
+// getEnum parses an optional string argument that must be one of the allowed values.
+func getEnum(request mcp.CallToolRequest, argName string, allowed []string, errInvalid error) (string, error) {
+	value := mcp.ParseString(request, argName, "")
+	if value != "" && !slices.Contains(allowed, value) {
+		return "", errInvalid
+	}
+
+	return value, nil
+}
+
+func getListToolArguments(request mcp.CallToolRequest, types []string, errInvalidType error) (listToolArgs, error) {
+	status, err := getEnum(request, toolArgStatus, policyStatuses, ErrInvalidStatus)
+	if err != nil {
+		return listToolArgs{}, err
+	}
+
+	policyType, err := getEnum(request, toolArgType, types, errInvalidType)
+	if err != nil {
+		return listToolArgs{}, err
+	}
+
+	//nolint:exhaustruct
+	args := listToolArgs{
+		workspaceID: mcp.ParseString(request, toolArgWorkspaceID, ""),
+		status:      status,
+		policyType:  policyType,
+	}
+
+	// Handle optional integer arguments.
+	currentPage := mcp.ParseInt(request, toolArgCurrentPage, 0)
+	if currentPage > 0 {
+		args.currentPage = &currentPage
+	} else if currentPage < 0 {
+		return listToolArgs{}, ErrInvalidCurrentPage
+	}
+
+	pageSize := mcp.ParseInt(request, toolArgPageSize, 0)
+	if pageSize > 0 {
+		args.pageSize = &pageSize
+	} else if pageSize < 0 {
+		return listToolArgs{}, ErrInvalidPageSize
+	}
+
+	return args, nil
+}
+
+// deleteHandler calls the Portkey Delete Policy API for the given policy collection and returns the result.
+func deleteHandler(portkey config.Portkey, path string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		policyID, err := getPolicyID(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodDelete,
+			URL:    createURL(portkey, path, policyID),
+			Body:   nil,
+		}, nil)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, DeleteResponse{ID: policyID, Deleted: true}), nil
+	}
+}
+
+// createURL returns the URL of a policy collection, or of a single policy when an ID is provided.
+func createURL(portkey config.Portkey, path, policyID string) string {
+	if policyID == "" {
+		return portkey.BaseURL + path
+	}
+
+	return fmt.Sprintf("%s%s/%s", portkey.BaseURL, path, url.PathEscape(policyID))
+}
+
+func createListURL(portkey config.Portkey, path string, args listToolArgs) string {
+	baseURL := createURL(portkey, path, "")
+
+	// Add query parameters.
+	values := url.Values{}
+	if args.workspaceID != "" {
+		values.Add(apiParamWorkspaceID, args.workspaceID)
+	}
+
+	if args.status != "" {
+		values.Add(apiParamStatus, args.status)
+	}
+
+	if args.policyType != "" {
+		values.Add(apiParamType, args.policyType)
+	}
+
+	if args.currentPage != nil {
+		values.Add(apiParamCurrentPage, strconv.Itoa(*args.currentPage))
+	}
+
+	if args.pageSize != nil {
+		values.Add(apiParamPageSize, strconv.Itoa(*args.pageSize))
+	}
+
+	if len(values) > 0 {
+		return baseURL + "?" + values.Encode()
+	}
+
+	return baseURL
+}
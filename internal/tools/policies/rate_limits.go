@@ -0,0 +1,52 @@
+package policies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// Tool arguments specific to rate limits policies.
+	toolArgUnit  = "unit"
+	toolArgValue = "value"
+)
+
+var (
+	ErrInvalidRateLimitType = fmt.Errorf("%s must be one of: %s", toolArgType, strings.Join(rateLimitTypes, ", "))
+	ErrInvalidRateLimitUnit = fmt.Errorf("%s must be one of: %s", toolArgUnit, strings.Join(rateLimitUnits, ", "))
+	ErrInvalidRateLimit     = fmt.Errorf("%s must be a positive number", toolArgValue)
+)
+
+//nolint:gochecknoglobals
+var (
+	rateLimitTypes = []string{"requests", "tokens"}
+	rateLimitUnits = []string{"rpm", "rph", "rpd"}
+)
+
+func withRateLimitUnit(opts ...mcp.PropertyOption) mcp.ToolOption {
+	opts = append(opts, mcp.Enum(rateLimitUnits...))
+
+	return mcp.WithString(toolArgUnit, opts...)
+}
+
+func withRateLimitValue(opts ...mcp.PropertyOption) mcp.ToolOption {
+	opts = append(opts, mcp.Min(0))
+
+	return mcp.WithNumber(toolArgValue, opts...)
+}
+
+// getRateLimitValue parses the optional rate limit value argument. A nil value is returned if it is absent.
+func getRateLimitValue(request mcp.CallToolRequest) (*float64, error) {
+	if mcp.ParseArgument(request, toolArgValue, nil) == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	value := mcp.ParseFloat64(request, toolArgValue, 0)
+	if value <= 0 {
+		return nil, ErrInvalidRateLimit
+	}
+
+	return &value, nil
+}
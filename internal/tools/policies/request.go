@@ -0,0 +1,59 @@
+package policies
+
+// UsageLimitCreateRequest represents the request body for the Portkey Create Usage Limits Policy API.
+type UsageLimitCreateRequest struct {
+	// Required arguments
+	Name        string      `json:"name"`
+	Conditions  []Condition `json:"conditions"`
+	GroupBy     []GroupBy   `json:"group_by"`
+	Type        string      `json:"type"`
+	CreditLimit float64     `json:"credit_limit"`
+
+	// Optional arguments
+	AlertThreshold *float64 `json:"alert_threshold,omitempty"`
+	PeriodicReset  string   `json:"periodic_reset,omitempty"`
+	WorkspaceID    string   `json:"workspace_id,omitempty"`
+}
+
+// UsageLimitUpdateRequest represents the request body for the Portkey Update Usage Limits Policy API.
+type UsageLimitUpdateRequest struct {
+	// Optional arguments
+	Name               string   `json:"name,omitempty"`
+	CreditLimit        *float64 `json:"credit_limit,omitempty"`
+	AlertThreshold     *float64 `json:"alert_threshold,omitempty"`
+	PeriodicReset      string   `json:"periodic_reset,omitempty"`
+	ResetUsageForValue string   `json:"reset_usage_for_value,omitempty"`
+}
+
+// RateLimitCreateRequest represents the request body for the Portkey Create Rate Limits Policy API.
+type RateLimitCreateRequest struct {
+	// Required arguments
+	Name       string      `json:"name"`
+	Conditions []Condition `json:"conditions"`
+	GroupBy    []GroupBy   `json:"group_by"`
+	Type       string      `json:"type"`
+	Unit       string      `json:"unit"`
+	Value      float64     `json:"value"`
+
+	// Optional arguments
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+// RateLimitUpdateRequest represents the request body for the Portkey Update Rate Limits Policy API.
+type RateLimitUpdateRequest struct {
+	// Optional arguments
+	Name  string   `json:"name,omitempty"`
+	Unit  string   `json:"unit,omitempty"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+// Condition restricts which requests a policy applies to, e.g. {"key": "api_key", "value": "<api key id>"}.
+type Condition struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GroupBy splits a policy's limit into separate buckets, e.g. {"key": "workspace_id"} for a limit per workspace.
+type GroupBy struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,105 @@
+package policies
+
+import "time"
+
+// UsageLimitListResponse represents the full response structure from the Portkey List Usage Limits Policies API.
+type UsageLimitListResponse struct {
+	Data  []UsageLimit `json:"data"`
+	Total int          `json:"total"`
+}
+
+// UsageLimit represents a single usage limits policy.
+type UsageLimit struct {
+	ID             string      `json:"id"`
+	Name           string      `json:"name"`
+	Conditions     []Condition `json:"conditions"`
+	GroupBy        []GroupBy   `json:"group_by"`
+	Type           string      `json:"type"`
+	CreditLimit    float64     `json:"credit_limit"`
+	AlertThreshold *float64    `json:"alert_threshold,omitempty"`
+	PeriodicReset  string      `json:"periodic_reset,omitempty"`
+	Status         string      `json:"status"`
+	WorkspaceID    string      `json:"workspace_id,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	LastUpdatedAt  time.Time   `json:"last_updated_at"`
+
+	// ValueKeys holds the current usage of each group of a policy. It is only included when retrieving a policy.
+	ValueKeys []ValueKeyUsage `json:"value_keys,omitempty"`
+}
+
+// ValueKeyUsage is the current usage of one group of a usage limits policy, e.g. one workspace.
+type ValueKeyUsage struct {
+	ValueKey     string     `json:"value_key"`
+	CurrentUsage float64    `json:"current_usage"`
+	Status       string     `json:"status,omitempty"`
+	LastResetAt  *time.Time `json:"last_reset_at,omitempty"`
+}
+
+// RateLimitListResponse represents the full response structure from the Portkey List Rate Limits Policies API.
+type RateLimitListResponse struct {
+	Data  []RateLimit `json:"data"`
+	Total int         `json:"total"`
+}
+
+// RateLimit represents a single rate limits policy.
+type RateLimit struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	Conditions    []Condition `json:"conditions"`
+	GroupBy       []GroupBy   `json:"group_by"`
+	Type          string      `json:"type"`
+	Unit          string      `json:"unit"`
+	Value         float64     `json:"value"`
+	Status        string      `json:"status"`
+	WorkspaceID   string      `json:"workspace_id,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	LastUpdatedAt time.Time   `json:"last_updated_at"`
+}
+
+// MutationResponse represents the response from the Portkey Create and Update Policy APIs.
+type MutationResponse struct {
+	ID     string `json:"id"`
+	Object string `json:"object,omitempty"`
+}
+
+// DeleteResponse is returned by the delete tools once a policy has been deleted.
+type DeleteResponse struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ConsumptionReport is returned by the usage_limits_consumption tool.
+type ConsumptionReport struct {
+	WarnAtPercent float64       `json:"warn_at_percent"`
+	Limits        []Consumption `json:"limits"`
+
+	// Truncated is true when there were more policies than could be reported on.
+	Truncated bool `json:"truncated"`
+
+	// Errors lists the policies that couldn't be retrieved, and so aren't reported on.
+	Errors []ConsumptionError `json:"errors,omitempty"`
+}
+
+// ConsumptionError is a usage limits policy that couldn't be retrieved for a consumption report.
+type ConsumptionError struct {
+	PolicyID   string `json:"policy_id"`
+	PolicyName string `json:"policy_name,omitempty"`
+	Error      string `json:"error"`
+}
+
+// Consumption is the current usage of one group of a usage limits policy, measured against the policy's limit.
+type Consumption struct {
+	PolicyID       string   `json:"policy_id"`
+	PolicyName     string   `json:"policy_name"`
+	Type           string   `json:"type"`
+	ValueKey       string   `json:"value_key,omitempty"`
+	CurrentUsage   float64  `json:"current_usage"`
+	CreditLimit    float64  `json:"credit_limit"`
+	AlertThreshold *float64 `json:"alert_threshold,omitempty"`
+	PercentUsed    float64  `json:"percent_used"`
+	Remaining      float64  `json:"remaining"`
+	PeriodicReset  string   `json:"periodic_reset,omitempty"`
+
+	// Level is "ok", "warning" (at or above the alert threshold or warn percentage) or "exceeded".
+	Level string `json:"level"`
+}
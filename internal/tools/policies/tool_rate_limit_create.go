@@ -0,0 +1,140 @@
+package policies
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const rateLimitCreateToolName = "rate_limit_create"
+
+func NewRateLimitCreateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Create a rate limits policy in your Portkey account. Requests from a group over its rate are " +
+		"rejected until the window passes."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	createTool := mcp.NewTool(
+		rateLimitCreateToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Rate Limit"),
+		mcp.WithOutputSchema[MutationResponse](),
+		mcp.WithString(toolArgName,
+			mcp.Required(),
+			mcp.Description("Name of the policy to create."),
+		),
+		withConditions(),
+		withGroupBy(),
+		mcp.WithString(toolArgType,
+			mcp.Required(),
+			mcp.Description("What the policy limits: requests or tokens."),
+			mcp.Enum(rateLimitTypes...),
+		),
+		withRateLimitUnit(
+			mcp.Required(),
+			mcp.Description("The window of the limit: per minute (rpm), per hour (rph) or per day (rpd)."),
+		),
+		withRateLimitValue(
+			mcp.Required(),
+			mcp.Description("The maximum requests or tokens each group may use per window."),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. The workspace to create the policy in."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: rateLimitCreateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// rateLimitCreateHandler calls the Portkey Create Rate Limits Policy API and returns the result.
+func rateLimitCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getRateLimitCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, rateLimitsPath, ""),
+			Body:   req,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getRateLimitCreateToolArguments(request mcp.CallToolRequest) (RateLimitCreateRequest, error) {
+	name := mcp.ParseString(request, toolArgName, "")
+	if name == "" {
+		return RateLimitCreateRequest{}, ErrNameRequired
+	}
+
+	conditions, err := getConditions(request)
+	if err != nil {
+		return RateLimitCreateRequest{}, err
+	}
+
+	groupBy, err := getGroupBy(request)
+	if err != nil {
+		return RateLimitCreateRequest{}, err
+	}
+
+	limitType, err := getEnum(request, toolArgType, rateLimitTypes, ErrInvalidRateLimitType)
+	if err != nil {
+		return RateLimitCreateRequest{}, err
+	}
+
+	if limitType == "" {
+		return RateLimitCreateRequest{}, ErrTypeRequired
+	}
+
+	unit, err := getEnum(request, toolArgUnit, rateLimitUnits, ErrInvalidRateLimitUnit)
+	if err != nil {
+		return RateLimitCreateRequest{}, err
+	}
+
+	if unit == "" {
+		return RateLimitCreateRequest{}, ErrInvalidRateLimitUnit
+	}
+
+	value, err := getRateLimitValue(request)
+	if err != nil {
+		return RateLimitCreateRequest{}, err
+	}
+
+	if value == nil {
+		return RateLimitCreateRequest{}, ErrInvalidRateLimit
+	}
+
+	return RateLimitCreateRequest{
+		Name:        name,
+		Conditions:  conditions,
+		GroupBy:     groupBy,
+		Type:        limitType,
+		Unit:        unit,
+		Value:       *value,
+		WorkspaceID: mcp.ParseString(request, toolArgWorkspaceID, ""),
+	}, nil
+}
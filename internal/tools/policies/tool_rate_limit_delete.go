@@ -0,0 +1,32 @@
+package policies
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+)
+
+const rateLimitDeleteToolName = "rate_limit_delete"
+
+func NewRateLimitDeleteTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Delete a rate limits policy from your Portkey account."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	deleteTool := mcp.NewTool(
+		rateLimitDeleteToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Delete Rate Limit"),
+		mcp.WithOutputSchema[DeleteResponse](),
+		withPolicyID("rate limits"),
+	)
+
+	return tools.Tuple{
+		Tool:    &deleteTool,
+		Handler: deleteHandler(portkeyCfg, rateLimitsPath),
+		Enabled: toolCfg.Enabled,
+	}
+}
@@ -0,0 +1,111 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const rateLimitUpdateToolName = "rate_limit_update"
+
+var ErrNothingToUpdateRateLimit = errors.New("at least one of name, unit or value is required")
+
+type rateLimitUpdateToolArgs struct {
+	policyID string
+	request  RateLimitUpdateRequest
+}
+
+func NewRateLimitUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Update the name, window or value of an existing Portkey rate limits policy. The policy's " +
+		"conditions and group_by keys can't be changed."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	updateTool := mcp.NewTool(
+		rateLimitUpdateToolName,
+		mcp.WithDescription(description),
+		tools.UpdateAnnotation("Update Rate Limit"),
+		mcp.WithOutputSchema[MutationResponse](),
+		withPolicyID("rate limits"),
+		mcp.WithString(toolArgName,
+			mcp.Description("Optional. The new name of the policy."),
+		),
+		withRateLimitUnit(
+			mcp.Description("Optional. The new window of the limit: per minute (rpm), per hour (rph) or per day (rpd)."),
+		),
+		withRateLimitValue(
+			mcp.Description("Optional. The new maximum requests or tokens each group may use per window."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &updateTool,
+		Handler: rateLimitUpdateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// rateLimitUpdateHandler calls the Portkey Update Rate Limits Policy API and returns the result.
+func rateLimitUpdateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getRateLimitUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, rateLimitsPath, args.policyID),
+			Body:   args.request,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getRateLimitUpdateToolArguments(request mcp.CallToolRequest) (rateLimitUpdateToolArgs, error) {
+	policyID, err := getPolicyID(request)
+	if err != nil {
+		return rateLimitUpdateToolArgs{}, err
+	}
+
+	unit, err := getEnum(request, toolArgUnit, rateLimitUnits, ErrInvalidRateLimitUnit)
+	if err != nil {
+		return rateLimitUpdateToolArgs{}, err
+	}
+
+	value, err := getRateLimitValue(request)
+	if err != nil {
+		return rateLimitUpdateToolArgs{}, err
+	}
+
+	req := RateLimitUpdateRequest{
+		Name:  mcp.ParseString(request, toolArgName, ""),
+		Unit:  unit,
+		Value: value,
+	}
+
+	if req == (RateLimitUpdateRequest{}) { //nolint:exhaustruct
+		return rateLimitUpdateToolArgs{}, ErrNothingToUpdateRateLimit
+	}
+
+	return rateLimitUpdateToolArgs{policyID: policyID, request: req}, nil
+}
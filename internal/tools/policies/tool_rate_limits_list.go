@@ -0,0 +1,64 @@
+package policies
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const rateLimitsListToolName = "rate_limits_list"
+
+func NewRateLimitsListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the rate limits policies in your Portkey account. A rate limits policy caps the requests " +
+		"or tokens per minute, hour or day that matching requests may use, tracked separately for each group_by value."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := append([]mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Rate Limits"),
+		mcp.WithOutputSchema[RateLimitListResponse](),
+	}, withListArguments(rateLimitTypes)...)
+	listTool := mcp.NewTool(rateLimitsListToolName, opts...)
+
+	return tools.Tuple{
+		Tool:    &listTool,
+		Handler: rateLimitsListHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// rateLimitsListHandler calls the Portkey List Rate Limits Policies API and returns the result.
+func rateLimitsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getListToolArguments(request, rateLimitTypes, ErrInvalidRateLimitType)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp RateLimitListResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createListURL(portkey, rateLimitsPath, args),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
@@ -0,0 +1,135 @@
+package policies
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const usageLimitCreateToolName = "usage_limit_create"
+
+func NewUsageLimitCreateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Create a usage limits policy in your Portkey account. Once a group reaches its credit limit, " +
+		"Portkey rejects its further requests until the usage is reset."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	createTool := mcp.NewTool(
+		usageLimitCreateToolName,
+		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Usage Limit"),
+		mcp.WithOutputSchema[MutationResponse](),
+		mcp.WithString(toolArgName,
+			mcp.Required(),
+			mcp.Description("Name of the policy to create."),
+		),
+		withConditions(),
+		withGroupBy(),
+		mcp.WithString(toolArgType,
+			mcp.Required(),
+			mcp.Description("What the policy limits: cost (in USD) or tokens."),
+			mcp.Enum(usageLimitTypes...),
+		),
+		withCreditLimit(
+			mcp.Required(),
+			mcp.Description("The maximum cost or tokens each group may use."),
+		),
+		withAlertThreshold(),
+		withPeriodicReset(),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. The workspace to create the policy in."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &createTool,
+		Handler: usageLimitCreateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// usageLimitCreateHandler calls the Portkey Create Usage Limits Policy API and returns the result.
+func usageLimitCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		req, err := getUsageLimitCreateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPost,
+			URL:    createURL(portkey, usageLimitsPath, ""),
+			Body:   req,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getUsageLimitCreateToolArguments(request mcp.CallToolRequest) (UsageLimitCreateRequest, error) {
+	name := mcp.ParseString(request, toolArgName, "")
+	if name == "" {
+		return UsageLimitCreateRequest{}, ErrNameRequired
+	}
+
+	conditions, err := getConditions(request)
+	if err != nil {
+		return UsageLimitCreateRequest{}, err
+	}
+
+	groupBy, err := getGroupBy(request)
+	if err != nil {
+		return UsageLimitCreateRequest{}, err
+	}
+
+	limitType, err := getEnum(request, toolArgType, usageLimitTypes, ErrInvalidUsageLimitType)
+	if err != nil {
+		return UsageLimitCreateRequest{}, err
+	}
+
+	if limitType == "" {
+		return UsageLimitCreateRequest{}, ErrTypeRequired
+	}
+
+	creditLimit, alertThreshold, err := getLimitAndThreshold(request)
+	if err != nil {
+		return UsageLimitCreateRequest{}, err
+	}
+
+	if creditLimit == nil {
+		return UsageLimitCreateRequest{}, ErrInvalidCreditLimit
+	}
+
+	periodicReset, err := getEnum(request, toolArgPeriodicReset, periodicResets, ErrInvalidPeriodicReset)
+	if err != nil {
+		return UsageLimitCreateRequest{}, err
+	}
+
+	return UsageLimitCreateRequest{
+		Name:           name,
+		Conditions:     conditions,
+		GroupBy:        groupBy,
+		Type:           limitType,
+		CreditLimit:    *creditLimit,
+		AlertThreshold: alertThreshold,
+		PeriodicReset:  periodicReset,
+		WorkspaceID:    mcp.ParseString(request, toolArgWorkspaceID, ""),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package policies
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+)
+
+const usageLimitDeleteToolName = "usage_limit_delete"
+
+func NewUsageLimitDeleteTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Delete a usage limits policy from your Portkey account. Requests it was blocking will be " +
+		"allowed again."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	deleteTool := mcp.NewTool(
+		usageLimitDeleteToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Delete Usage Limit"),
+		mcp.WithOutputSchema[DeleteResponse](),
+		withPolicyID("usage limits"),
+	)
+
+	return tools.Tuple{
+		Tool:    &deleteTool,
+		Handler: deleteHandler(portkeyCfg, usageLimitsPath),
+		Enabled: toolCfg.Enabled,
+	}
+}
@@ -0,0 +1,116 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const usageLimitUpdateToolName = "usage_limit_update"
+
+var ErrNothingToUpdateUsageLimit = errors.New(
+	"at least one of name, credit_limit, alert_threshold, periodic_reset or reset_usage_for_value is required")
+
+type usageLimitUpdateToolArgs struct {
+	policyID string
+	request  UsageLimitUpdateRequest
+}
+
+func NewUsageLimitUpdateTool(portkeyCfg config.Portkey, toolCfg config.WriteTool) tools.Tuple {
+	description := "Update an existing Portkey usage limits policy, or reset the usage of one of its groups. The " +
+		"policy's conditions and group_by keys can't be changed."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	updateTool := mcp.NewTool(
+		usageLimitUpdateToolName,
+		mcp.WithDescription(description),
+		tools.DestructiveAnnotation("Update Usage Limit"),
+		mcp.WithOutputSchema[MutationResponse](),
+		withPolicyID("usage limits"),
+		mcp.WithString(toolArgName,
+			mcp.Description("Optional. The new name of the policy."),
+		),
+		withCreditLimit(
+			mcp.Description("Optional. The new maximum cost or tokens each group may use."),
+		),
+		withAlertThreshold(),
+		withPeriodicReset(),
+		mcp.WithString(toolArgResetUsageForValue,
+			mcp.Description("Optional. A group_by value whose usage should be reset to zero, e.g. an API key ID."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &updateTool,
+		Handler: usageLimitUpdateHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// usageLimitUpdateHandler calls the Portkey Update Usage Limits Policy API and returns the result.
+func usageLimitUpdateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getUsageLimitUpdateToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp MutationResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodPut,
+			URL:    createURL(portkey, usageLimitsPath, args.policyID),
+			Body:   args.request,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getUsageLimitUpdateToolArguments(request mcp.CallToolRequest) (usageLimitUpdateToolArgs, error) {
+	policyID, err := getPolicyID(request)
+	if err != nil {
+		return usageLimitUpdateToolArgs{}, err
+	}
+
+	creditLimit, alertThreshold, err := getLimitAndThreshold(request)
+	if err != nil {
+		return usageLimitUpdateToolArgs{}, err
+	}
+
+	periodicReset, err := getEnum(request, toolArgPeriodicReset, periodicResets, ErrInvalidPeriodicReset)
+	if err != nil {
+		return usageLimitUpdateToolArgs{}, err
+	}
+
+	req := UsageLimitUpdateRequest{
+		Name:               mcp.ParseString(request, toolArgName, ""),
+		CreditLimit:        creditLimit,
+		AlertThreshold:     alertThreshold,
+		PeriodicReset:      periodicReset,
+		ResetUsageForValue: mcp.ParseString(request, toolArgResetUsageForValue, ""),
+	}
+
+	if req == (UsageLimitUpdateRequest{}) { //nolint:exhaustruct
+		return usageLimitUpdateToolArgs{}, ErrNothingToUpdateUsageLimit
+	}
+
+	return usageLimitUpdateToolArgs{policyID: policyID, request: req}, nil
+}
@@ -0,0 +1,250 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	usageLimitsConsumptionToolName = "usage_limits_consumption"
+
+	// Tool arguments.
+	toolArgWarnAtPercent = "warn_at_percent"
+
+	defaultWarnAtPercent = 80.0
+
+	// maxConsumptionPolicies bounds how many policies are reported on when no policy_id is given, as each one is
+	// retrieved individually. They are listed consumptionPageSize at a time, and retrieved consumptionConcurrency at a
+	// time.
+	maxConsumptionPolicies = 200
+	consumptionPageSize    = 100
+	consumptionConcurrency = 8
+)
+
+var (
+	ErrInvalidWarnAtPercent = fmt.Errorf("%s must be a number between 0 and 100", toolArgWarnAtPercent)
+	ErrPolicyAndWorkspace   = fmt.Errorf("%s and %s can't be used together", toolArgPolicyID, toolArgWorkspaceID)
+)
+
+type consumptionToolArgs struct {
+	policyID      string
+	workspaceID   string
+	warnAtPercent float64
+}
+
+func NewUsageLimitsConsumptionTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Report how much of each Portkey usage limit has been consumed. For every group of every " +
+		"active usage limits policy, this returns the current usage, the limit, the percentage used and the " +
+		"remaining budget, flagging groups at or above their alert threshold as 'warning' and groups at their " +
+		"limit as 'exceeded'. The most consumed limits are listed first. " +
+		fmt.Sprintf("At most %d policies are reported on, and the report is marked as truncated if there are "+
+			"more. Policies that couldn't be retrieved are listed under 'errors'.", maxConsumptionPolicies)
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	consumptionTool := mcp.NewTool(
+		usageLimitsConsumptionToolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Usage Limits Consumption"),
+		mcp.WithOutputSchema[ConsumptionReport](),
+		mcp.WithString(toolArgPolicyID,
+			mcp.Description(fmt.Sprintf("Optional. Only report on this usage limits policy. Can't be used with %s.",
+				toolArgWorkspaceID)),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description(fmt.Sprintf("Optional. Only report on policies in this workspace. Can't be used with %s.",
+				toolArgPolicyID)),
+		),
+		mcp.WithNumber(toolArgWarnAtPercent,
+			mcp.Description(fmt.Sprintf("Optional. Also flag groups as 'warning' once they've used this percentage "+
+				"of their limit. Defaults to %g.", defaultWarnAtPercent)),
+			mcp.Min(0),
+			mcp.Max(percent),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &consumptionTool,
+		Handler: usageLimitsConsumptionHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// usageLimitsConsumptionHandler retrieves usage limits policies with their current usage from the Portkey API and
+// reports the consumption of each against its limit.
+func usageLimitsConsumptionHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getConsumptionToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		if args.policyID != "" {
+			var limit UsageLimit
+
+			errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+				Method: http.MethodGet,
+				URL:    createURL(portkey, usageLimitsPath, args.policyID),
+				Body:   nil,
+			}, &limit)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			return tools.NewToolResultJSON(lgr, ConsumptionReport{
+				WarnAtPercent: args.warnAtPercent,
+				Limits:        MeasureConsumption([]UsageLimit{limit}, args.warnAtPercent),
+				Truncated:     false,
+				Errors:        nil,
+			}), nil
+		}
+
+		listed, truncated, errResult := fetchActiveUsageLimits(ctx, portkey, args.workspaceID)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		limits, errs := fetchUsageLimits(ctx, portkey, listed)
+
+		return tools.NewToolResultJSON(lgr, ConsumptionReport{
+			WarnAtPercent: args.warnAtPercent,
+			Limits:        MeasureConsumption(limits, args.warnAtPercent),
+			Truncated:     truncated,
+			Errors:        errs,
+		}), nil
+	}
+}
+
+// fetchActiveUsageLimits pages through the List Usage Limits Policies API, up to maxConsumptionPolicies. It reports
+// whether there were more policies than that.
+func fetchActiveUsageLimits(
+	ctx context.Context,
+	portkey config.Portkey,
+	workspaceID string,
+) ([]UsageLimit, bool, *mcp.CallToolResult) {
+	lgr := middleware.GetLogger(ctx)
+
+	var limits []UsageLimit
+
+	for page := 1; ; page++ {
+		size := consumptionPageSize
+
+		var portkeyResp UsageLimitListResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL: createListURL(portkey, usageLimitsPath, listToolArgs{
+				workspaceID: workspaceID,
+				status:      statusActive,
+				policyType:  "",
+				currentPage: &page,
+				pageSize:    &size,
+			}),
+			Body: nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return nil, false, errResult
+		}
+
+		limits = append(limits, portkeyResp.Data...)
+
+		// The total is only trusted when the response has one.
+		done := len(portkeyResp.Data) < consumptionPageSize ||
+			(portkeyResp.Total > 0 && len(limits) >= portkeyResp.Total)
+
+		switch {
+		case len(limits) > maxConsumptionPolicies:
+			lgr.Warn("too many usage limits policies to report on, returning a partial report",
+				"max_policies", maxConsumptionPolicies)
+
+			return limits[:maxConsumptionPolicies], true, nil
+		case done:
+			return limits, false, nil
+		}
+	}
+}
+
+// fetchUsageLimits retrieves each of the listed policies with its current usage, which the list API doesn't include,
+// consumptionConcurrency at a time. Policies that can't be retrieved are reported as errors, rather than failing the
+// whole report.
+func fetchUsageLimits(
+	ctx context.Context,
+	portkey config.Portkey,
+	listed []UsageLimit,
+) ([]UsageLimit, []ConsumptionError) {
+	results := make([]UsageLimit, len(listed))
+	errResults := make([]*mcp.CallToolResult, len(listed))
+	sem := make(chan struct{}, consumptionConcurrency)
+
+	var wg sync.WaitGroup
+
+	for i, policy := range listed {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			errResults[i] = tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+				Method: http.MethodGet,
+				URL:    createURL(portkey, usageLimitsPath, policy.ID),
+				Body:   nil,
+			}, &results[i])
+		})
+	}
+
+	wg.Wait()
+
+	limits := make([]UsageLimit, 0, len(listed))
+
+	var errs []ConsumptionError
+
+	for i, policy := range listed {
+		if errResults[i] != nil {
+			errs = append(errs, ConsumptionError{
+				PolicyID:   policy.ID,
+				PolicyName: policy.Name,
+				Error:      tools.ResultError(errResults[i]).Error(),
+			})
+
+			continue
+		}
+
+		limits = append(limits, results[i])
+	}
+
+	return limits, errs
+}
+
+func getConsumptionToolArguments(request mcp.CallToolRequest) (consumptionToolArgs, error) {
+	warnAtPercent := mcp.ParseFloat64(request, toolArgWarnAtPercent, defaultWarnAtPercent)
+	if warnAtPercent < 0 || warnAtPercent > percent {
+		return consumptionToolArgs{}, ErrInvalidWarnAtPercent
+	}
+
+	policyID := mcp.ParseString(request, toolArgPolicyID, "")
+	workspaceID := mcp.ParseString(request, toolArgWorkspaceID, "")
+
+	if policyID != "" && workspaceID != "" {
+		return consumptionToolArgs{}, ErrPolicyAndWorkspace
+	}
+
+	return consumptionToolArgs{
+		policyID:      policyID,
+		workspaceID:   workspaceID,
+		warnAtPercent: warnAtPercent,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package policies_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/policies"
+)
+
+// fakeUsageLimits serves count active usage limits policies, without a total, and fails to retrieve those in failing.
+// It records the most policies that were retrieved at once.
+type fakeUsageLimits struct {
+	count   int
+	failing map[string]bool
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (f *fakeUsageLimits) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/policies/usage-limits" {
+		page, _ := strconv.Atoi(r.URL.Query().Get("current_page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+		data := []policies.UsageLimit{}
+		for i := (page - 1) * size; i < min(page*size, f.count); i++ {
+			data = append(data, policies.UsageLimit{ID: fmt.Sprintf("pol_%d", i)}) //nolint:exhaustruct
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+
+		return
+	}
+
+	inFlight := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+
+	for {
+		highest := f.maxInFlight.Load()
+		if inFlight <= highest || f.maxInFlight.CompareAndSwap(highest, inFlight) {
+			break
+		}
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/policies/usage-limits/")
+	if f.failing[id] {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(policies.UsageLimit{ID: id, CreditLimit: 100}) //nolint:exhaustruct
+}
+
+func callConsumption(t *testing.T, baseURL string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	tool := policies.NewUsageLimitsConsumptionTool(
+		config.Portkey{APIKey: "test-key", APIKeyMode: config.APIKeyModeServer, BaseURL: baseURL}, //nolint:exhaustruct
+		config.BaseTool{Description: "", Enabled: true},
+	)
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+
+	result, err := tool.Handler(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	return result
+}
+
+func TestUsageLimitsConsumptionReportsPolicyErrors(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeUsageLimits{count: 30, failing: map[string]bool{"pol_3": true, "pol_17": true}} //nolint:exhaustruct
+	portkey := httptest.NewServer(fake)
+	t.Cleanup(portkey.Close)
+
+	result := callConsumption(t, portkey.URL, map[string]any{})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result.Content)
+	}
+
+	report, ok := result.StructuredContent.(policies.ConsumptionReport)
+	if !ok {
+		t.Fatalf("structured content is %T, want policies.ConsumptionReport", result.StructuredContent)
+	}
+
+	if len(report.Limits) != 28 || report.Truncated {
+		t.Errorf("got %d limits, truncated %v, want 28, false", len(report.Limits), report.Truncated)
+	}
+
+	if len(report.Errors) != 2 || report.Errors[0].PolicyID != "pol_3" || report.Errors[1].PolicyID != "pol_17" {
+		t.Errorf("errors = %+v, want pol_3 and pol_17", report.Errors)
+	}
+
+	if highest := fake.maxInFlight.Load(); highest > 8 {
+		t.Errorf("retrieved %d policies at once, want at most 8", highest)
+	}
+}
+
+func TestUsageLimitsConsumptionTruncates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		count         int
+		wantTruncated bool
+	}{
+		{name: "at the cap", count: 200, wantTruncated: false},
+		{name: "over the cap", count: 250, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			portkey := httptest.NewServer(&fakeUsageLimits{count: tt.count}) //nolint:exhaustruct
+			t.Cleanup(portkey.Close)
+
+			result := callConsumption(t, portkey.URL, map[string]any{})
+
+			report, ok := result.StructuredContent.(policies.ConsumptionReport)
+			if !ok {
+				t.Fatalf("structured content is %T, want policies.ConsumptionReport", result.StructuredContent)
+			}
+
+			if len(report.Limits) != 200 || report.Truncated != tt.wantTruncated {
+				t.Errorf("got %d limits, truncated %v, want 200, %v",
+					len(report.Limits), report.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestUsageLimitsConsumptionRejectsPolicyInWorkspace(t *testing.T) {
+	t.Parallel()
+
+	result := callConsumption(t, "http://127.0.0.1:0", map[string]any{
+		"policy_id":    "pol_1",
+		"workspace_id": "ws_1",
+	})
+	if !result.IsError {
+		t.Errorf("expected an error result for policy_id with workspace_id, got %+v", result.Content)
+	}
+}
@@ -0,0 +1,65 @@
+package policies
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const usageLimitsListToolName = "usage_limits_list"
+
+func NewUsageLimitsListTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the usage limits policies in your Portkey account. A usage limits policy caps the cost or " +
+		"tokens that matching requests may consume, tracked separately for each group_by value. Use " +
+		"usage_limits_consumption to see how close each limit is to being reached."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	opts := append([]mcp.ToolOption{
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Usage Limits"),
+		mcp.WithOutputSchema[UsageLimitListResponse](),
+	}, withListArguments(usageLimitTypes)...)
+	listTool := mcp.NewTool(usageLimitsListToolName, opts...)
+
+	return tools.Tuple{
+		Tool:    &listTool,
+		Handler: usageLimitsListHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// usageLimitsListHandler calls the Portkey List Usage Limits Policies API and returns the result.
+func usageLimitsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getListToolArguments(request, usageLimitTypes, ErrInvalidUsageLimitType)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp UsageLimitListResponse
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createListURL(portkey, usageLimitsPath, args),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
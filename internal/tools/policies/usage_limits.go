@@ -0,0 +1,79 @@
+package policies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// Tool arguments specific to usage limits policies.
+	toolArgCreditLimit        = "credit_limit"
+	toolArgAlertThreshold     = "alert_threshold"
+	toolArgPeriodicReset      = "periodic_reset"
+	toolArgResetUsageForValue = "reset_usage_for_value"
+)
+
+var (
+	ErrInvalidUsageLimitType = fmt.Errorf("%s must be one of: %s", toolArgType, strings.Join(usageLimitTypes, ", "))
+	ErrInvalidPeriodicReset  = fmt.Errorf("%s must be one of: %s", toolArgPeriodicReset,
+		strings.Join(periodicResets, ", "))
+	ErrInvalidCreditLimit    = fmt.Errorf("%s must be a positive number", toolArgCreditLimit)
+	ErrInvalidAlertThreshold = fmt.Errorf("%s must be a positive number no greater than %s", toolArgAlertThreshold,
+		toolArgCreditLimit)
+)
+
+//nolint:gochecknoglobals
+var (
+	usageLimitTypes = []string{"cost", "tokens"}
+	periodicResets  = []string{"monthly", "weekly"}
+)
+
+func withCreditLimit(opts ...mcp.PropertyOption) mcp.ToolOption {
+	opts = append(opts, mcp.Min(0))
+
+	return mcp.WithNumber(toolArgCreditLimit, opts...)
+}
+
+func withAlertThreshold() mcp.ToolOption {
+	return mcp.WithNumber(toolArgAlertThreshold,
+		mcp.Description("Optional. Usage, in the same unit as credit_limit, at which Portkey sends an alert. Must "+
+			"not exceed credit_limit."),
+		mcp.Min(0),
+	)
+}
+
+func withPeriodicReset() mcp.ToolOption {
+	return mcp.WithString(toolArgPeriodicReset,
+		mcp.Description("Optional. Reset usage to zero at the start of every week or month. Without it, the "+
+			"limit applies to all usage."),
+		mcp.Enum(periodicResets...),
+	)
+}
+
+// getLimitAndThreshold parses the credit limit and alert threshold arguments. Either may be absent, but when both
+// are present the threshold must not exceed the limit.
+func getLimitAndThreshold(request mcp.CallToolRequest) (*float64, *float64, error) {
+	var creditLimit, alertThreshold *float64
+
+	if mcp.ParseArgument(request, toolArgCreditLimit, nil) != nil {
+		value := mcp.ParseFloat64(request, toolArgCreditLimit, 0)
+		if value <= 0 {
+			return nil, nil, ErrInvalidCreditLimit
+		}
+
+		creditLimit = &value
+	}
+
+	if mcp.ParseArgument(request, toolArgAlertThreshold, nil) != nil {
+		value := mcp.ParseFloat64(request, toolArgAlertThreshold, 0)
+		if value <= 0 || (creditLimit != nil && value > *creditLimit) {
+			return nil, nil, ErrInvalidAlertThreshold
+		}
+
+		alertThreshold = &value
+	}
+
+	return creditLimit, alertThreshold, nil
+}
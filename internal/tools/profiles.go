@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const toolArgProfile = "profile"
+
+// WithProfiles returns a copy of the tuple whose tool takes an optional profile argument, and whose handler calls the
+// handler of the chosen profile. The tool's description lists the profiles, so that the agent can choose between them.
+func WithProfiles(
+	tuple Tuple,
+	handlers map[string]server.ToolHandlerFunc,
+	names []string,
+	defaultProfile string,
+) Tuple {
+	tool := *tuple.Tool
+	tool.Description = fmt.Sprintf("%s\n\nThis tool can be used with any of these Portkey profiles: %s. Unless a "+
+		"profile is given, the '%s' profile is used.", tool.Description, strings.Join(names, ", "), defaultProfile)
+
+	// The properties map is shared with the original tool, so it is copied before being added to.
+	tool.InputSchema.Properties = maps.Clone(tool.InputSchema.Properties)
+
+	mcp.WithString(toolArgProfile,
+		mcp.Description(fmt.Sprintf("Optional. The Portkey profile (org or gateway) to call. Defaults to '%s'.",
+			defaultProfile)),
+		mcp.Enum(names...),
+	)(&tool)
+
+	return Tuple{
+		Tool:    &tool,
+		Handler: profileHandler(handlers, names, defaultProfile),
+		Enabled: tuple.Enabled,
+	}
+}
+
+// profileHandler calls the handler of the profile named in the request, or of the default profile if none is named.
+func profileHandler(
+	handlers map[string]server.ToolHandlerFunc,
+	names []string,
+	defaultProfile string,
+) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		profile := mcp.ParseString(request, toolArgProfile, defaultProfile)
+
+		handler, ok := handlers[profile]
+		if !ok {
+			middleware.GetLogger(ctx).Info("unknown portkey profile requested", "profile", profile)
+
+			return mcp.NewToolResultError(fmt.Sprintf("invalid input: unknown profile %q, must be one of: %s",
+				profile, strings.Join(names, ", "))), nil
+		}
+
+		ctx = middleware.ContextWithLogger(ctx, middleware.GetLogger(ctx).With("portkey_profile", profile))
+
+		return handler(ctx, request)
+	}
+}
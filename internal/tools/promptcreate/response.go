@@ -1,5 +1,7 @@
 package promptcreate
 
+import "fmt"
+
 // Response represents the full response structure from the Portkey Prompt Create API.
 type Response struct {
 	ID        string `json:"id"`
@@ -7,3 +9,8 @@ type Response struct {
 	VersionID string `json:"version_id"`
 	Object    string `json:"object"`
 }
+
+// Markdown describes the created prompt.
+func (r Response) Markdown() string {
+	return fmt.Sprintf("Created prompt `%s` (ID `%s`, version `%s`).", r.Slug, r.ID, r.VersionID)
+}
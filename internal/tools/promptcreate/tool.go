@@ -14,6 +14,7 @@ import (
 
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/format"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
 )
 
@@ -58,7 +59,7 @@ type toolArgs struct {
 	templateMetadata   map[string]any
 }
 
-func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+func NewTool(portkeyCfg config.Portkey, resultsCfg config.Results, toolCfg config.BaseTool) tools.Tuple {
 	description := "Create a new prompt in your Portkey account with the provided arguments. " +
 		"This tool allows you to create a prompt with a name, template string, parameters, " +
 		"and other optional settings."
@@ -70,6 +71,9 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 	promptCreateTool := mcp.NewTool(
 		toolName,
 		mcp.WithDescription(description),
+		tools.CreateAnnotation("Create Prompt"),
+		mcp.WithOutputSchema[Response](),
+		format.WithOutputFormatArgument(resultsCfg),
 		mcp.WithString(toolArgName,
 			mcp.Required(),
 			mcp.Description("Name of the prompt to create."),
@@ -116,7 +120,7 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 
 	return tools.Tuple{
 		Tool:    &promptCreateTool,
-		Handler: promptCreateHandler(portkeyCfg),
+		Handler: promptCreateHandler(portkeyCfg, resultsCfg),
 		Enabled: toolCfg.Enabled,
 	}
 }
@@ -124,7 +128,7 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 // promptCreateHandler calls the Portkey Prompt Create API and returns the result.
 // Note: For validation errors (e.g. missing required fields), specific error messages are returned.
 // For internal/system errors, generic error messages are returned while details are logged.
-func promptCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
+func promptCreateHandler(portkey config.Portkey, resultsCfg config.Results) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		lgr := middleware.GetLogger(ctx)
 
@@ -135,6 +139,13 @@ func promptCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
 		}
 
+		formatOpts, err := format.GetOptions(request, resultsCfg)
+		if err != nil {
+			lgr.Info("failed to get user-provided output format from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
 		url := portkey.BaseURL + "/prompts"
 
 		body, err := createReqBody(args)
@@ -152,13 +163,14 @@ func promptCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("X-Portkey-Api-Key", string(portkey.APIKey))
+
+		if errResult := tools.SetAPIKeyHeader(ctx, httpReq, portkey); errResult != nil {
+			return errResult, nil
+		}
 
 		resp, err := tools.MakePortkeyAPIRequest(ctx, httpReq)
 		if err != nil {
-			lgr.Error("failed to call portkey api", "error", err)
-
-			return mcp.NewToolResultError("failed to communicate with portkey service"), nil
+			return tools.HandleRequestError(err, lgr), nil
 		}
 		defer resp.Body.Close()
 
@@ -180,7 +192,7 @@ func promptCreateHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("received invalid response from portkey service"), nil
 		}
 
-		return mcp.NewToolResultText(string(respBody)), nil
+		return format.NewResult(lgr, formatOpts, portkeyResp, respBody), nil
 	}
 }
 
@@ -238,7 +250,7 @@ func getToolArguments(request mcp.CallToolRequest) (toolArgs, error) {
 }
 
 func extractArrayOfObjects(request mcp.CallToolRequest, argName string) ([]map[string]any, error) {
-	rawValue, exists := request.Params.Arguments[argName]
+	rawValue, exists := request.GetArguments()[argName]
 	if !exists || rawValue == nil {
 		return nil, nil
 	}
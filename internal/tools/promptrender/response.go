@@ -1,5 +1,10 @@
 package promptrender
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Response represents the full response structure from the Portkey Prompt Render API.
 type Response struct {
 	Success bool       `json:"success"`
@@ -64,3 +69,32 @@ type Function struct {
 	Description string         `json:"description,omitempty"`
 	Parameters  map[string]any `json:"parameters,omitempty"`
 }
+
+// Markdown lists the rendered messages role by role.
+func (r Response) Markdown() string {
+	var sb strings.Builder
+
+	if r.Data.Model != "" {
+		fmt.Fprintf(&sb, "**Model:** %s\n", r.Data.Model)
+	}
+
+	for _, msg := range r.Data.Messages {
+		role := msg.Role
+		if msg.Name != "" {
+			role = fmt.Sprintf("%s (%s)", msg.Role, msg.Name)
+		}
+
+		fmt.Fprintf(&sb, "\n### %s\n\n%s\n", role, msg.Content)
+	}
+
+	if len(r.Data.Tools) > 0 {
+		names := make([]string, 0, len(r.Data.Tools))
+		for _, tool := range r.Data.Tools {
+			names = append(names, tool.Function.Name)
+		}
+
+		fmt.Fprintf(&sb, "\n**Tools:** %s\n", strings.Join(names, ", "))
+	}
+
+	return strings.TrimLeft(sb.String(), "\n")
+}
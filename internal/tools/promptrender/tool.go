@@ -13,7 +13,9 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/retry"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/format"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
 )
 
@@ -38,7 +40,7 @@ type toolArgs struct {
 	variables map[string]string
 }
 
-func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+func NewTool(portkeyCfg config.Portkey, resultsCfg config.Results, toolCfg config.BaseTool) tools.Tuple {
 	description := "Render a Portkey prompt template by prompt slug and return the raw payload. This is a way to obtain " +
 		"a prompt with optional variables substituted in. You can select specific versions of a prompt, or use the " +
 		"currently published version."
@@ -50,6 +52,9 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 	promptRenderTool := mcp.NewTool(
 		toolName,
 		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Render Prompt"),
+		mcp.WithOutputSchema[Response](),
+		format.WithOutputFormatArgument(resultsCfg),
 		mcp.WithString(toolArgPromptID,
 			mcp.Required(),
 			mcp.Description("The ID of the Portkey prompt to render. Specifically, this is the 'slug' of the prompt, if you "+
@@ -66,7 +71,7 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 
 	return tools.Tuple{
 		Tool:    &promptRenderTool,
-		Handler: promptRenderHandler(portkeyCfg),
+		Handler: promptRenderHandler(portkeyCfg, resultsCfg),
 		Enabled: toolCfg.Enabled,
 	}
 }
@@ -74,7 +79,7 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 // promptRenderHandler calls the Portkey Prompt Render API and returns the result.
 // Note: For validation errors (e.g. missing required fields), specific error messages are returned.
 // For internal/system errors, generic error messages are returned while details are logged.
-func promptRenderHandler(portkey config.Portkey) server.ToolHandlerFunc {
+func promptRenderHandler(portkey config.Portkey, resultsCfg config.Results) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		lgr := middleware.GetLogger(ctx)
 
@@ -85,6 +90,13 @@ func promptRenderHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
 		}
 
+		formatOpts, err := format.GetOptions(request, resultsCfg)
+		if err != nil {
+			lgr.Info("failed to get user-provided output format from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
 		url := createURL(portkey, args)
 
 		body, err := createReqBody(args)
@@ -94,7 +106,13 @@ func promptRenderHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultError(errTextInternalError), nil
 		}
 
-		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		// Rendering changes nothing, so it is retried like a GET, unless the operator turned that off.
+		reqCtx := ctx
+		if portkey.Client.Retry.AllowRender {
+			reqCtx = retry.Allow(ctx)
+		}
+
+		httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
 		if err != nil {
 			lgr.Error("failed to create http request", "error", err)
 
@@ -102,13 +120,14 @@ func promptRenderHandler(portkey config.Portkey) server.ToolHandlerFunc {
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("X-Portkey-Api-Key", string(portkey.APIKey))
+
+		if errResult := tools.SetAPIKeyHeader(ctx, httpReq, portkey); errResult != nil {
+			return errResult, nil
+		}
 
 		resp, err := tools.MakePortkeyAPIRequest(ctx, httpReq)
 		if err != nil {
-			lgr.Error("failed to call portkey api", "error", err)
-
-			return mcp.NewToolResultError("failed to communicate with portkey service"), nil
+			return tools.HandleRequestError(err, lgr), nil
 		}
 		defer resp.Body.Close()
 
@@ -136,7 +155,7 @@ func promptRenderHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("portkey service reported failure"), nil
 		}
 
-		return mcp.NewToolResultText(string(respBody)), nil
+		return format.NewResult(lgr, formatOpts, portkeyResp, respBody), nil
 	}
 }
 
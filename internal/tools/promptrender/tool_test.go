@@ -0,0 +1,78 @@
+package promptrender_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/retry"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/promptrender"
+)
+
+// TestPromptRenderRetry makes sure that renders, which are POSTs, are only retried when the operator allows it.
+func TestPromptRenderRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		allowRender  bool
+		wantAttempts int32
+	}{
+		{name: "allowed", allowRender: true, wantAttempts: 3},
+		{name: "not allowed", allowRender: false, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			t.Cleanup(portkey.Close)
+
+			retryCfg := config.Retry{
+				MaxAttempts: 3,
+				BaseBackoff: time.Millisecond,
+				MaxBackoff:  time.Millisecond,
+				Jitter:      0,
+				StatusCodes: []int{http.StatusServiceUnavailable},
+				AllowRender: tt.allowRender,
+			}
+
+			portkeyCfg := config.Portkey{ //nolint:exhaustruct
+				APIKey:     "test-key",
+				APIKeyMode: config.APIKeyModeServer,
+				BaseURL:    portkey.URL,
+				Client:     config.HTTPClient{Retry: retryCfg}, //nolint:exhaustruct
+			}
+
+			tool := promptrender.NewTool(portkeyCfg,
+				config.Results{DefaultFormat: config.ResultFormatJSON, PreserveUnknownFields: false},
+				config.BaseTool{Description: "", Enabled: true})
+
+			ctx := middleware.ContextWithHTTPClient(t.Context(),
+				&http.Client{Transport: retry.Transport(retryCfg, nil)}) //nolint:exhaustruct
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = map[string]any{"prompt_id": "my-prompt"}
+
+			if _, err := tool.Handler(ctx, request); err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
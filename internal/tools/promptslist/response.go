@@ -1,6 +1,11 @@
 package promptslist
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/format"
+)
 
 // Response represents the full response structure from the Portkey List Prompts API.
 type Response struct {
@@ -20,3 +25,34 @@ type PromptData struct {
 	LastUpdatedAt time.Time `json:"last_updated_at"`
 	Object        string    `json:"object"`
 }
+
+// Markdown summarizes the prompts as a table.
+func (r Response) Markdown() string {
+	if len(r.Data) == 0 {
+		return "No prompts found."
+	}
+
+	rows := make([][]string, 0, len(r.Data))
+
+	for _, prompt := range r.Data {
+		rows = append(rows, []string{
+			prompt.Name,
+			prompt.Slug,
+			prompt.CollectionID,
+			prompt.Model,
+			prompt.Status,
+			formatTime(prompt.LastUpdatedAt),
+		})
+	}
+
+	return fmt.Sprintf("Showing %d of %d prompts.\n\n", len(r.Data), r.Total) +
+		format.Table([]string{"Name", "Slug", "Collection", "Model", "Status", "Last updated"}, rows)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
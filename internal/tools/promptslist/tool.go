@@ -14,6 +14,7 @@ import (
 
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/format"
 	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
 )
 
@@ -50,7 +51,7 @@ type toolArgs struct {
 	search       string
 }
 
-func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+func NewTool(portkeyCfg config.Portkey, resultsCfg config.Results, toolCfg config.BaseTool) tools.Tuple {
 	description := "List all prompts in your Portkey account after applying the provided arguments. This tool allows " +
 		"you to retrieve prompt metadata like prompt ID, prompt slug, name, collection, model, and status. " +
 		"You can filter by various parameters and paginate results. There is the ability to search by " +
@@ -63,6 +64,9 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 	listPromptsTool := mcp.NewTool(
 		toolName,
 		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Prompts"),
+		mcp.WithOutputSchema[Response](),
+		format.WithOutputFormatArgument(resultsCfg),
 		mcp.WithString(toolArgCollectionID,
 			mcp.Description("Optional. Filter prompts by collection ID."),
 		),
@@ -82,13 +86,13 @@ func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
 
 	return tools.Tuple{
 		Tool:    &listPromptsTool,
-		Handler: promptsListHandler(portkeyCfg),
+		Handler: promptsListHandler(portkeyCfg, resultsCfg),
 		Enabled: toolCfg.Enabled,
 	}
 }
 
 // promptsListHandler calls the Portkey Prompts List API and returns the result.
-func promptsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+func promptsListHandler(portkey config.Portkey, resultsCfg config.Results) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		lgr := middleware.GetLogger(ctx)
 
@@ -99,6 +103,13 @@ func promptsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
 		}
 
+		formatOpts, err := format.GetOptions(request, resultsCfg)
+		if err != nil {
+			lgr.Info("failed to get user-provided output format from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
 		apiURL := createURL(portkey, args)
 
 		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
@@ -109,13 +120,14 @@ func promptsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("X-Portkey-Api-Key", string(portkey.APIKey))
+
+		if errResult := tools.SetAPIKeyHeader(ctx, httpReq, portkey); errResult != nil {
+			return errResult, nil
+		}
 
 		resp, err := tools.MakePortkeyAPIRequest(ctx, httpReq)
 		if err != nil {
-			lgr.Error("failed to call portkey api", "error", err)
-
-			return mcp.NewToolResultError("failed to communicate with portkey service"), nil
+			return tools.HandleRequestError(err, lgr), nil
 		}
 		defer resp.Body.Close()
 
@@ -137,7 +149,7 @@ func promptsListHandler(portkey config.Portkey) server.ToolHandlerFunc {
 			return mcp.NewToolResultError("received invalid response from portkey service"), nil
 		}
 
-		return mcp.NewToolResultText(string(respBody)), nil
+		return format.NewResult(lgr, formatOpts, portkeyResp, respBody), nil
 	}
 }
 
@@ -0,0 +1,22 @@
+package providerslist
+
+import "time"
+
+// Response represents the full response structure from the Portkey List Providers API.
+type Response struct {
+	Data  []Provider `json:"data"`
+	Total int        `json:"total"`
+}
+
+// Provider is an integration made available in a workspace, which requests can route to with its slug.
+type Provider struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
+	IntegrationID string    `json:"integration_id,omitempty"`
+	AIProviderID  string    `json:"ai_provider_id,omitempty"`
+	Status        string    `json:"status"`
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+}
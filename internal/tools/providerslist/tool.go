@@ -0,0 +1,148 @@
+package providerslist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	toolName = "providers_list"
+
+	// Tool arguments.
+	toolArgWorkspaceID = "workspace_id"
+	toolArgCurrentPage = "current_page"
+	toolArgPageSize    = "page_size"
+
+	// Portkey API query parameters.
+	apiParamWorkspaceID = "workspace_id"
+	apiParamCurrentPage = "current_page"
+	apiParamPageSize    = "page_size"
+)
+
+var (
+	ErrInvalidPageSize    = fmt.Errorf("%s must be a positive integer", toolArgPageSize)
+	ErrInvalidCurrentPage = fmt.Errorf("%s must be a positive integer", toolArgCurrentPage)
+)
+
+type toolArgs struct {
+	workspaceID string
+	currentPage *int
+	pageSize    *int
+}
+
+func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "List the AI providers available in a Portkey workspace. Each provider comes from an integration " +
+		"shared with the workspace, and its slug is what requests use to route to it (e.g. '@openai-prod/gpt-4o')."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	providersListTool := mcp.NewTool(
+		toolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("List Providers"),
+		mcp.WithOutputSchema[Response](),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. The workspace to list providers for. Required when using an organisation "+
+				"admin API key."),
+		),
+		mcp.WithNumber(toolArgCurrentPage,
+			mcp.Description("Optional. Page number for pagination. Starts at 1."),
+		),
+		mcp.WithNumber(toolArgPageSize,
+			mcp.Description("Optional. Number of results per page."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &providersListTool,
+		Handler: providersListHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// providersListHandler calls the Portkey List Providers API and returns the result.
+func providersListHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		var portkeyResp Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    createURL(portkey, args),
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		return tools.NewToolResultJSON(lgr, portkeyResp), nil
+	}
+}
+
+func getToolArguments(request mcp.CallToolRequest) (toolArgs, error) {
+	//nolint:exhaustruct
+	args := toolArgs{
+		workspaceID: mcp.ParseString(request, toolArgWorkspaceID, ""),
+	}
+
+	// Handle optional integer arguments.
+	currentPage := mcp.ParseInt(request, toolArgCurrentPage, 0)
+	if currentPage > 0 {
+		args.currentPage = &currentPage
+	} else if currentPage < 0 {
+		return toolArgs{}, ErrInvalidCurrentPage
+	}
+
+	pageSize := mcp.ParseInt(request, toolArgPageSize, 0)
+	if pageSize > 0 {
+		args.pageSize = &pageSize
+	} else if pageSize < 0 {
+		return toolArgs{}, ErrInvalidPageSize
+	}
+
+	return args, nil
+}
+
+func createURL(portkey config.Portkey, args toolArgs) string {
+	baseURL := portkey.BaseURL + "/providers"
+
+	// Add query parameters.
+	values := url.Values{}
+	if args.workspaceID != "" {
+		values.Add(apiParamWorkspaceID, args.workspaceID)
+	}
+
+	if args.currentPage != nil {
+		values.Add(apiParamCurrentPage, strconv.Itoa(*args.currentPage))
+	}
+
+	if args.pageSize != nil {
+		values.Add(apiParamPageSize, strconv.Itoa(*args.pageSize))
+	}
+
+	if len(values) > 0 {
+		return baseURL + "?" + values.Encode()
+	}
+
+	return baseURL
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithOutputSchemaAnyOf declares that the structured content of a tool matches the output schema of either A or B, for
+// tools whose result shape depends on their arguments.
+func WithOutputSchemaAnyOf[A, B any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		schema, err := json.Marshal(map[string]any{
+			"type":  "object",
+			"anyOf": []json.RawMessage{outputSchema[A](), outputSchema[B]()},
+		})
+		if err != nil {
+			slog.Error("failed to marshal output schema", "tool", tool.Name, "error", err)
+
+			return
+		}
+
+		tool.RawOutputSchema = schema
+	}
+}
+
+// outputSchema returns the output schema that mcp.WithOutputSchema declares for T.
+func outputSchema[T any]() json.RawMessage {
+	var tool mcp.Tool
+
+	mcp.WithOutputSchema[T]()(&tool)
+
+	schema, err := json.Marshal(tool.OutputSchema)
+	if err != nil {
+		return json.RawMessage(`{"type":"object"}`)
+	}
+
+	return schema
+}
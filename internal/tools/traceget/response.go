@@ -0,0 +1,82 @@
+package traceget
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Response represents the reconstructed trace returned by the trace_get tool.
+type Response struct {
+	TraceID string  `json:"trace_id"`
+	Summary Summary `json:"summary"`
+	Spans   []*Span `json:"spans"`
+
+	// Truncated is true when the trace had more logs than this tool is willing to fetch.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Summary holds totals across every log entry in a trace.
+type Summary struct {
+	LogCount    int     `json:"log_count"`
+	ErrorCount  int     `json:"error_count"`
+	TotalTokens int     `json:"total_tokens"`
+	TotalCost   float64 `json:"total_cost"`
+}
+
+// Span is a single node of the reconstructed span tree. Each span corresponds to one log entry.
+type Span struct {
+	LogID        string    `json:"log_id"`
+	SpanID       string    `json:"span_id,omitempty"`
+	ParentSpanID string    `json:"parent_span_id,omitempty"`
+	SpanName     string    `json:"span_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	StatusCode   int       `json:"status_code"`
+	AIModel      string    `json:"ai_model,omitempty"`
+	LatencyMS    int64     `json:"latency_ms"`
+	TotalTokens  int       `json:"total_tokens"`
+	Cost         float64   `json:"cost"`
+	Children     []*Span   `json:"children,omitempty"`
+}
+
+// outputSchema is the JSON schema of Response. It is written by hand because spans nest recursively, which schemas
+// generated from Go types don't support.
+//
+//nolint:gochecknoglobals
+var outputSchema = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"trace_id": {"type": "string"},
+		"summary": {
+			"type": "object",
+			"properties": {
+				"log_count": {"type": "integer"},
+				"error_count": {"type": "integer"},
+				"total_tokens": {"type": "integer"},
+				"total_cost": {"type": "number"}
+			},
+			"required": ["log_count", "error_count", "total_tokens", "total_cost"]
+		},
+		"spans": {"type": ["null", "array"], "items": {"$ref": "#/$defs/span"}},
+		"truncated": {"type": "boolean"}
+	},
+	"required": ["trace_id", "summary", "spans"],
+	"$defs": {
+		"span": {
+			"type": "object",
+			"properties": {
+				"log_id": {"type": "string"},
+				"span_id": {"type": "string"},
+				"parent_span_id": {"type": "string"},
+				"span_name": {"type": "string"},
+				"created_at": {"type": "string"},
+				"status_code": {"type": "integer"},
+				"ai_model": {"type": "string"},
+				"latency_ms": {"type": "integer"},
+				"total_tokens": {"type": "integer"},
+				"cost": {"type": "number"},
+				"children": {"type": "array", "items": {"$ref": "#/$defs/span"}}
+			},
+			"required": ["log_id", "created_at", "status_code", "latency_ms", "total_tokens", "cost"]
+		}
+	}
+}`)
@@ -0,0 +1,172 @@
+package traceget
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logssearch"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+)
+
+const (
+	toolName = "trace_get"
+
+	// Tool arguments.
+	toolArgTraceID     = "trace_id"
+	toolArgWorkspaceID = "workspace_id"
+
+	// pageSize is the number of logs requested per page while collecting a trace.
+	pageSize = 100
+
+	// maxPages bounds the number of pages fetched for a single trace, so that runaway traces can't stall the tool.
+	maxPages = 20
+
+	errTextInternalError = "internal error while processing request"
+)
+
+var ErrTraceIDRequired = errors.New("trace_id is required")
+
+type toolArgs struct {
+	traceID     string
+	workspaceID string
+}
+
+func NewTool(portkeyCfg config.Portkey, toolCfg config.BaseTool) tools.Tuple {
+	description := "Reconstruct a Portkey trace. This tool fetches every log entry that shares the given trace ID, " +
+		"rebuilds the parent/child span tree, and returns it as nested JSON followed by a compact, indented text view " +
+		"with per-span latency, tokens, cost and status. Use this to debug multi-step agent runs."
+
+	if toolCfg.Description != "" {
+		description = toolCfg.Description
+	}
+
+	traceGetTool := mcp.NewTool(
+		toolName,
+		mcp.WithDescription(description),
+		tools.ReadOnlyAnnotation("Get Trace"),
+		mcp.WithRawOutputSchema(outputSchema),
+		mcp.WithString(toolArgTraceID,
+			mcp.Required(),
+			mcp.Description("The trace ID to reconstruct."),
+		),
+		mcp.WithString(toolArgWorkspaceID,
+			mcp.Description("Optional. Only look for logs in this workspace."),
+		),
+	)
+
+	return tools.Tuple{
+		Tool:    &traceGetTool,
+		Handler: traceGetHandler(portkeyCfg),
+		Enabled: toolCfg.Enabled,
+	}
+}
+
+// traceGetHandler collects every log of a trace from the Portkey Logs API and returns the reconstructed span tree.
+func traceGetHandler(portkey config.Portkey) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lgr := middleware.GetLogger(ctx)
+
+		args, err := getToolArguments(request)
+		if err != nil {
+			lgr.Info("failed to get user-provided tool arguments from mcp request", "error", err)
+
+			return mcp.NewToolResultErrorFromErr("invalid input", err), nil
+		}
+
+		entries, truncated, errResult := fetchTraceLogs(ctx, portkey, args)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if len(entries) == 0 {
+			return mcp.NewToolResultError("no logs found for trace"), nil
+		}
+
+		roots := BuildTree(entries)
+
+		result := tools.NewToolResultJSON(lgr, Response{
+			TraceID:   args.traceID,
+			Summary:   Summarize(entries),
+			Spans:     roots,
+			Truncated: truncated,
+		})
+		if result.IsError {
+			return result, nil
+		}
+
+		result.Content = append(result.Content, mcp.NewTextContent(RenderTree(roots)))
+
+		return result, nil
+	}
+}
+
+// fetchTraceLogs pages through the Logs API until every log of the trace has been collected, or maxPages is reached,
+// in which case the trace is reported as truncated.
+func fetchTraceLogs(
+	ctx context.Context,
+	portkey config.Portkey,
+	args toolArgs,
+) ([]logssearch.LogEntry, bool, *mcp.CallToolResult) {
+	lgr := middleware.GetLogger(ctx)
+
+	var entries []logssearch.LogEntry
+
+	for page := 1; page <= maxPages; page++ {
+		size := pageSize
+
+		apiURL, err := logssearch.CreateURL(portkey, logssearch.Request{ //nolint:exhaustruct
+			TraceID:     args.traceID,
+			WorkspaceID: args.workspaceID,
+			CurrentPage: &page,
+			PageSize:    &size,
+		})
+		if err != nil {
+			lgr.Error("failed to create url", "error", err)
+
+			return nil, false, mcp.NewToolResultError(errTextInternalError)
+		}
+
+		var portkeyResp logssearch.Response
+
+		errResult := tools.CallPortkeyAPI(ctx, portkey, tools.PortkeyRequest{
+			Method: http.MethodGet,
+			URL:    apiURL,
+			Body:   nil,
+		}, &portkeyResp)
+		if errResult != nil {
+			return nil, false, errResult
+		}
+
+		entries = append(entries, portkeyResp.Data...)
+
+		// The Logs API doesn't always include a total, so only a short page marks the end of the trace.
+		if len(portkeyResp.Data) < pageSize {
+			return entries, false, nil
+		}
+	}
+
+	lgr.Warn("trace has more logs than can be fetched, returning a partial trace",
+		"trace_id", args.traceID,
+		"log_count", len(entries),
+	)
+
+	return entries, true, nil
+}
+
+func getToolArguments(request mcp.CallToolRequest) (toolArgs, error) {
+	traceID := mcp.ParseString(request, toolArgTraceID, "")
+	if traceID == "" {
+		return toolArgs{}, ErrTraceIDRequired
+	}
+
+	return toolArgs{
+		traceID:     traceID,
+		workspaceID: mcp.ParseString(request, toolArgWorkspaceID, ""),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package traceget_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logssearch"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/traceget"
+)
+
+// TestTraceGetWithoutTotal makes sure that a trace is collected page by page when the Logs API doesn't say how many
+// logs there are.
+func TestTraceGetWithoutTotal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		logCount      int
+		wantLogs      int
+		wantTruncated bool
+	}{
+		{name: "several pages", logCount: 250, wantLogs: 250, wantTruncated: false},
+		{name: "exactly full pages", logCount: 200, wantLogs: 200, wantTruncated: false},
+		{name: "more than max pages", logCount: 2500, wantLogs: 2000, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("current_page"))
+				size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+				data := []logssearch.LogEntry{}
+				for i := (page - 1) * size; i < min(page*size, tt.logCount); i++ {
+					data = append(data, logssearch.LogEntry{ID: fmt.Sprintf("log-%d", i)}) //nolint:exhaustruct
+				}
+
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+			}))
+			t.Cleanup(portkey.Close)
+
+			portkeyCfg := config.Portkey{ //nolint:exhaustruct
+				APIKey:     "test-key",
+				APIKeyMode: config.APIKeyModeServer,
+				BaseURL:    portkey.URL,
+			}
+
+			tool := traceget.NewTool(portkeyCfg, config.BaseTool{Description: "", Enabled: true})
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = map[string]any{"trace_id": "trace-1"}
+
+			result, err := tool.Handler(t.Context(), request)
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+
+			resp, ok := result.StructuredContent.(traceget.Response)
+			if !ok {
+				t.Fatalf("structured content is %T, want traceget.Response", result.StructuredContent)
+			}
+
+			if resp.Summary.LogCount != tt.wantLogs || resp.Truncated != tt.wantTruncated {
+				t.Errorf("got %d logs, truncated %v, want %d, %v",
+					resp.Summary.LogCount, resp.Truncated, tt.wantLogs, tt.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package traceget
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logssearch"
+)
+
+// BuildTree arranges log entries into a parent/child span tree, using each entry's span ID and parent span ID. Entries
+// whose parent is not part of the trace (or that have no parent) become roots. So does the earliest entry of each
+// cycle of parent links, so that every entry is part of the tree. Siblings are ordered by creation time.
+func BuildTree(entries []logssearch.LogEntry) []*Span {
+	spans := make([]*Span, 0, len(entries))
+	bySpanID := make(map[string]*Span, len(entries))
+
+	for _, entry := range entries {
+		span := &Span{
+			LogID:        entry.ID,
+			SpanID:       entry.SpanID,
+			ParentSpanID: entry.ParentSpanID,
+			SpanName:     entry.SpanName,
+			CreatedAt:    entry.CreatedAt,
+			StatusCode:   entry.StatusCode,
+			AIModel:      entry.AIModel,
+			LatencyMS:    entry.ResponseTimeMS,
+			TotalTokens:  entry.TotalTokens,
+			Cost:         entry.Cost,
+			Children:     nil,
+		}
+
+		spans = append(spans, span)
+
+		// If a span ID is logged more than once, children attach to the first entry seen.
+		if _, exists := bySpanID[span.SpanID]; span.SpanID != "" && !exists {
+			bySpanID[span.SpanID] = span
+		}
+	}
+
+	var roots []*Span
+
+	for _, span := range spans {
+		parent, ok := bySpanID[span.ParentSpanID]
+		if span.ParentSpanID == "" || !ok || parent == span {
+			roots = append(roots, span)
+
+			continue
+		}
+
+		parent.Children = append(parent.Children, span)
+	}
+
+	return sortSpans(append(roots, cycleRoots(spans, roots, bySpanID)...))
+}
+
+// cycleRoots detaches the spans whose parent links form a cycle, which aren't reachable from any root, from their
+// parents. It returns the earliest span of each cycle, which the rest of the cycle now descends from.
+func cycleRoots(spans, roots []*Span, bySpanID map[string]*Span) []*Span {
+	reached := make(map[*Span]bool, len(spans))
+
+	for _, root := range roots {
+		markReached(root, reached)
+	}
+
+	unreached := slices.DeleteFunc(slices.Clone(spans), func(span *Span) bool { return reached[span] })
+	slices.SortStableFunc(unreached, compareCreatedAt)
+
+	var promoted []*Span
+
+	for _, span := range unreached {
+		if reached[span] {
+			continue
+		}
+
+		// Each span has a single parent, so detaching one span of a cycle breaks it.
+		parent := bySpanID[span.ParentSpanID]
+		parent.Children = slices.DeleteFunc(parent.Children, func(child *Span) bool { return child == span })
+
+		promoted = append(promoted, span)
+		markReached(span, reached)
+	}
+
+	return promoted
+}
+
+func markReached(span *Span, reached map[*Span]bool) {
+	reached[span] = true
+
+	for _, child := range span.Children {
+		markReached(child, reached)
+	}
+}
+
+func sortSpans(spans []*Span) []*Span {
+	slices.SortStableFunc(spans, compareCreatedAt)
+
+	for _, span := range spans {
+		sortSpans(span.Children)
+	}
+
+	return spans
+}
+
+func compareCreatedAt(a, b *Span) int {
+	return a.CreatedAt.Compare(b.CreatedAt)
+}
+
+// Summarize totals the tokens, cost and errors of every log entry in a trace.
+func Summarize(entries []logssearch.LogEntry) Summary {
+	var summary Summary
+
+	for _, entry := range entries {
+		summary.LogCount++
+		summary.TotalTokens += entry.TotalTokens
+		summary.TotalCost += entry.Cost
+
+		if entry.StatusCode >= http.StatusBadRequest {
+			summary.ErrorCount++
+		}
+	}
+
+	return summary
+}
+
+// RenderTree returns a compact, indented text view of a span tree, with one line per span.
+func RenderTree(roots []*Span) string {
+	var sb strings.Builder
+
+	for _, root := range roots {
+		renderSpan(&sb, root, 0)
+	}
+
+	return sb.String()
+}
+
+func renderSpan(sb *strings.Builder, span *Span, depth int) {
+	name := span.SpanName
+	if name == "" {
+		name = "(unnamed)"
+	}
+
+	id := span.SpanID
+	if id == "" {
+		id = "log:" + span.LogID
+	}
+
+	fmt.Fprintf(sb, "%s- %s [%s]", strings.Repeat("  ", depth), name, id)
+
+	if span.AIModel != "" {
+		fmt.Fprintf(sb, " model=%s", span.AIModel)
+	}
+
+	fmt.Fprintf(sb, " status=%d latency=%dms tokens=%d cost=%g\n",
+		span.StatusCode, span.LatencyMS, span.TotalTokens, span.Cost)
+
+	for _, child := range span.Children {
+		renderSpan(sb, child, depth+1)
+	}
+}
@@ -0,0 +1,126 @@
+package traceget_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/logssearch"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/traceget"
+)
+
+func TestBuildTree(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	//nolint:exhaustruct
+	entries := []logssearch.LogEntry{
+		{ID: "log-3", SpanID: "tool", ParentSpanID: "root", SpanName: "tool call", CreatedAt: start.Add(2 * time.Second)},
+		{ID: "log-2", SpanID: "plan", ParentSpanID: "root", SpanName: "plan", CreatedAt: start.Add(time.Second)},
+		{ID: "log-1", SpanID: "root", SpanName: "agent run", CreatedAt: start, StatusCode: 200},
+		{ID: "log-4", SpanID: "orphan", ParentSpanID: "missing", CreatedAt: start.Add(3 * time.Second), StatusCode: 500},
+	}
+
+	roots := traceget.BuildTree(entries)
+
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(roots))
+	}
+
+	if roots[0].LogID != "log-1" || roots[1].LogID != "log-4" {
+		t.Errorf("unexpected root order: %s, %s", roots[0].LogID, roots[1].LogID)
+	}
+
+	children := roots[0].Children
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children of root span, got %d", len(children))
+	}
+
+	if children[0].SpanID != "plan" || children[1].SpanID != "tool" {
+		t.Errorf("children not ordered by creation time: %s, %s", children[0].SpanID, children[1].SpanID)
+	}
+
+	summary := traceget.Summarize(entries)
+	if summary.LogCount != 4 || summary.ErrorCount != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	tree := traceget.RenderTree(roots)
+	if !strings.Contains(tree, "\n  - plan [plan]") {
+		t.Errorf("expected indented child span in text view, got:\n%s", tree)
+	}
+}
+
+func TestBuildTreeKeepsEverySpan(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	//nolint:exhaustruct
+	tests := []struct {
+		name      string
+		entries   []logssearch.LogEntry
+		wantRoots []string
+	}{
+		{
+			name: "two-span cycle",
+			entries: []logssearch.LogEntry{
+				{ID: "log-b", SpanID: "b", ParentSpanID: "a", CreatedAt: start.Add(time.Second)},
+				{ID: "log-a", SpanID: "a", ParentSpanID: "b", CreatedAt: start},
+			},
+			wantRoots: []string{"log-a"},
+		},
+		{
+			name: "cycle with descendants next to a tree",
+			entries: []logssearch.LogEntry{
+				{ID: "log-root", SpanID: "root", CreatedAt: start},
+				{ID: "log-child", SpanID: "child", ParentSpanID: "root", CreatedAt: start.Add(time.Second)},
+				{ID: "log-c", SpanID: "c", ParentSpanID: "e", CreatedAt: start.Add(4 * time.Second)},
+				{ID: "log-d", SpanID: "d", ParentSpanID: "c", CreatedAt: start.Add(2 * time.Second)},
+				{ID: "log-e", SpanID: "e", ParentSpanID: "d", CreatedAt: start.Add(3 * time.Second)},
+				{ID: "log-leaf", SpanID: "leaf", ParentSpanID: "e", CreatedAt: start.Add(5 * time.Second)},
+			},
+			wantRoots: []string{"log-root", "log-d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			roots := traceget.BuildTree(tt.entries)
+
+			var rootIDs []string
+			for _, root := range roots {
+				rootIDs = append(rootIDs, root.LogID)
+			}
+
+			if strings.Join(rootIDs, ",") != strings.Join(tt.wantRoots, ",") {
+				t.Errorf("expected roots %v, got %v", tt.wantRoots, rootIDs)
+			}
+
+			seen := make(map[string]int)
+
+			var walk func(spans []*traceget.Span)
+			walk = func(spans []*traceget.Span) {
+				for _, span := range spans {
+					seen[span.LogID]++
+					walk(span.Children)
+				}
+			}
+			walk(roots)
+
+			for _, entry := range tt.entries {
+				if seen[entry.ID] != 1 {
+					t.Errorf("expected %s to appear once in the tree, got %d times", entry.ID, seen[entry.ID])
+				}
+			}
+
+			tree := traceget.RenderTree(roots)
+			if lines := strings.Count(tree, "\n"); lines != len(tt.entries) {
+				t.Errorf("expected %d lines in the text view, got %d:\n%s", len(tt.entries), lines, tree)
+			}
+		})
+	}
+}
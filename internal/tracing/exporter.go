@@ -0,0 +1,274 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/types"
+)
+
+var ErrExportRejected = errors.New("collector rejected spans")
+
+// otlpTracesPath is the path of the OTLP/HTTP traces receiver, relative to the collector's base URL.
+const otlpTracesPath = "/v1/traces"
+
+// OTLP status codes.
+const (
+	statusCodeUnset = 0
+	statusCodeError = 2
+)
+
+// exporter sends encoded spans somewhere.
+type exporter interface {
+	export(ctx context.Context, payload []byte) error
+	shutdown() error
+}
+
+func newExporter(cfg config.Tracing) (exporter, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterOTLP:
+		return &otlpExporter{
+			url:     strings.TrimSuffix(cfg.OTLPEndpoint, "/") + otlpTracesPath,
+			headers: cfg.OTLPHeaders,
+			client:  &http.Client{Timeout: exportTimeout}, //nolint:exhaustruct
+		}, nil
+	case config.TracingExporterFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:mnd
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tracing file: %w", err)
+		}
+
+		return &writerExporter{w: file, closer: file}, nil //nolint:exhaustruct
+	case config.TracingExporterStdout:
+		return &writerExporter{w: os.Stdout, closer: nil}, nil //nolint:exhaustruct
+	case config.TracingExporterNone:
+	}
+
+	return nil, fmt.Errorf("%w: %q", config.ErrInvalidTracingExporter, cfg.Exporter)
+}
+
+// otlpExporter sends spans to an OpenTelemetry collector, with OTLP over HTTP.
+type otlpExporter struct {
+	url     string
+	headers map[string]types.MaskedString
+	client  *http.Client
+}
+
+func (e *otlpExporter) export(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range e.headers {
+		req.Header.Set(name, string(value))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send spans: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: status %d", ErrExportRejected, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (e *otlpExporter) shutdown() error {
+	e.client.CloseIdleConnections()
+
+	return nil
+}
+
+// writerExporter writes spans as JSON lines, each of which is an OTLP export request, as read by the OpenTelemetry
+// collector's OTLP JSON file receiver.
+type writerExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+func (e *writerExporter) export(_ context.Context, payload []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, err := e.w.Write(append(payload, '\n')); err != nil {
+		return fmt.Errorf("failed to write spans: %w", err)
+	}
+
+	return nil
+}
+
+func (e *writerExporter) shutdown() error {
+	if e.closer == nil {
+		return nil
+	}
+
+	if err := e.closer.Close(); err != nil {
+		return fmt.Errorf("failed to close tracing file: %w", err)
+	}
+
+	return nil
+}
+
+// resource describes the service that spans come from.
+type resource struct {
+	serviceName    string
+	serviceVersion string
+}
+
+// The types below are the OTLP JSON encoding of an export request, whose field names are camel case. IDs are
+// hex-encoded, and 64-bit integers are strings, as the encoding requires.
+//
+//nolint:tagliatelle
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+//nolint:tagliatelle
+type resourceSpans struct {
+	Resource   otlpResource `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+//nolint:tagliatelle
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+//nolint:tagliatelle
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func encode(res resource, spans []*Span) ([]byte, error) {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, encodeSpan(span))
+	}
+
+	data, err := json.Marshal(exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: otlpResource{Attributes: encodeAttributes([]Attribute{
+				String("service.name", res.serviceName),
+				String("service.version", res.serviceVersion),
+			})},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName, Version: res.serviceVersion},
+				Spans: otlpSpans,
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spans: %w", err)
+	}
+
+	return data, nil
+}
+
+func encodeSpan(span *Span) otlpSpan {
+	span.mu.Lock()
+	defer span.mu.Unlock()
+
+	encoded := otlpSpan{
+		TraceID:           span.spanContext.TraceID.String(),
+		SpanID:            span.spanContext.SpanID.String(),
+		ParentSpanID:      "",
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        encodeAttributes(span.attributes),
+		Status:            status{Code: statusCodeUnset, Message: ""},
+	}
+
+	if span.parentSpanID.IsValid() {
+		encoded.ParentSpanID = span.parentSpanID.String()
+	}
+
+	if span.failed {
+		encoded.Status = status{Code: statusCodeError, Message: span.statusMessage}
+	}
+
+	return encoded
+}
+
+func encodeAttributes(attributes []Attribute) []keyValue {
+	encoded := make([]keyValue, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		var value anyValue
+
+		switch v := attribute.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		case bool:
+			value.BoolValue = &v
+		default:
+			s := fmt.Sprint(v)
+			value.StringValue = &s
+		}
+
+		encoded = append(encoded, keyValue{Key: attribute.Key, Value: value})
+	}
+
+	return encoded
+}
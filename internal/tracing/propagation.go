@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader is the W3C trace context header, which is also the key of the trace context in the _meta of MCP
+// requests.
+const TraceparentHeader = "traceparent"
+
+// Headers that line Portkey's trace view up with this server's traces.
+const (
+	headerPortkeyTraceID      = "X-Portkey-Trace-Id"
+	headerPortkeySpanID       = "X-Portkey-Span-Id"
+	headerPortkeyParentSpanID = "X-Portkey-Parent-Span-Id"
+	headerPortkeySpanName     = "X-Portkey-Span-Name"
+)
+
+// traceparentVersion is the only version of the traceparent header that is parsed and sent.
+const traceparentVersion = "00"
+
+// flagSampled marks spans as recorded. Every span is recorded, so it is always set.
+const flagSampled = "01"
+
+// ParseTraceparent parses a W3C traceparent header value, e.g.
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". It reports false if the value is malformed.
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || parts[0] != traceparentVersion {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+
+	if !decodeHex(parts[1], sc.TraceID[:]) || !decodeHex(parts[2], sc.SpanID[:]) {
+		return SpanContext{}, false
+	}
+
+	return sc, sc.IsValid()
+}
+
+// Traceparent formats the span context as a W3C traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("%s-%s-%s-%s", traceparentVersion, sc.TraceID, sc.SpanID, flagSampled)
+}
+
+// WithHTTPTraceContext continues the trace of the client that sent the HTTP request, if it sent a traceparent header.
+func WithHTTPTraceContext(ctx context.Context, r *http.Request) context.Context {
+	parent, ok := ParseTraceparent(r.Header.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+
+	return ContextWithRemoteParent(ctx, parent)
+}
+
+// injectHeaders adds the trace context of the span to an outgoing request, both as a W3C traceparent header and as
+// the headers that Portkey reads its own trace context from.
+func injectHeaders(header http.Header, span *Span) {
+	header.Set(TraceparentHeader, span.spanContext.Traceparent())
+	header.Set(headerPortkeyTraceID, span.spanContext.TraceID.String())
+	header.Set(headerPortkeySpanID, span.spanContext.SpanID.String())
+	header.Set(headerPortkeySpanName, span.name)
+
+	if span.parentSpanID.IsValid() {
+		header.Set(headerPortkeyParentSpanID, span.parentSpanID.String())
+	}
+}
+
+func decodeHex(s string, dst []byte) bool {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return false
+	}
+
+	_, err := hex.Decode(dst, []byte(s))
+
+	return err == nil
+}
@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace, across this server, its clients and Portkey.
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is non-zero, as zero IDs are invalid in W3C trace context.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is non-zero, as zero IDs are invalid in W3C trace context.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext is what identifies a span to other services.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid reports whether both IDs are valid.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// SpanKind is the OTLP kind of a span.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a key and a string, int64, float64 or bool value.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a timed operation within a trace. It is exported once it ends.
+type Span struct {
+	tracer *Tracer
+
+	name         string
+	kind         SpanKind
+	spanContext  SpanContext
+	parentSpanID SpanID
+	start        time.Time
+
+	mu            sync.Mutex
+	end           time.Time
+	attributes    []Attribute
+	failed        bool
+	statusMessage string
+	ended         bool
+}
+
+// SpanContext returns the IDs that identify the span to other services.
+func (s *Span) SpanContext() SpanContext {
+	return s.spanContext
+}
+
+// ParentSpanID returns the ID of the span's parent, which is invalid for the root span of a trace.
+func (s *Span) ParentSpanID() SpanID {
+	return s.parentSpanID
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// SetError marks the span as failed, with a description of the failure.
+func (s *Span) SetError(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed = true
+	s.statusMessage = message
+}
+
+// End ends the span and queues it for export. Only the first call has any effect.
+func (s *Span) End() {
+	s.mu.Lock()
+
+	if s.ended {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.enqueue(s)
+}
+
+type spanKey struct{}
+
+type remoteParentKey struct{}
+
+// ContextWithRemoteParent returns a copy of the context whose spans continue the trace of another service, like the
+// MCP client.
+func ContextWithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	if !parent.IsValid() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, remoteParentKey{}, parent)
+}
+
+// SpanFromContext returns the span in the context, or nil if there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+
+	return span
+}
+
+// parentFromContext returns the span context that a new span is a child of: the span in the context, or else the
+// remote parent. It is invalid when the new span starts a trace.
+func parentFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.spanContext
+	}
+
+	parent, _ := ctx.Value(remoteParentKey{}).(SpanContext)
+
+	return parent
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+
+	_, _ = rand.Read(id[:])
+
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+
+	_, _ = rand.Read(id[:])
+
+	return id
+}
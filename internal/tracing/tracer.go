@@ -0,0 +1,180 @@
+// Package tracing records OpenTelemetry spans for tool calls and the Portkey requests that they make, and propagates
+// their trace context to Portkey. Spans are exported in the OTLP JSON encoding, to a collector or to a file.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+const (
+	// queueSize bounds how many finished spans wait for export. Spans that don't fit are dropped, rather than slowing
+	// down tool calls.
+	queueSize = 2048
+
+	// maxBatchSize is how many spans are exported at once, at most.
+	maxBatchSize = 512
+
+	// exportTimeout bounds each export.
+	exportTimeout = 10 * time.Second
+)
+
+// scopeName is the instrumentation scope of every span.
+const scopeName = "github.com/rvoh-emccaleb/portkey-mcp-server"
+
+// Tracer starts spans, and exports them in batches in the background.
+type Tracer struct {
+	exporter     exporter
+	resource     resource
+	batchTimeout time.Duration
+
+	// mu guards closed, so that no span is queued once the queue has been closed.
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Span
+
+	dropped atomic.Int64
+	done    chan struct{}
+}
+
+// New returns a Tracer that exports spans as configured, and starts exporting in the background. Tracing must be
+// enabled.
+func New(cfg config.Tracing, appVersion string) (*Tracer, error) {
+	exp, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := &Tracer{ //nolint:exhaustruct
+		exporter:     exp,
+		resource:     resource{serviceName: cfg.ServiceName, serviceVersion: appVersion},
+		batchTimeout: cfg.BatchTimeout,
+		queue:        make(chan *Span, queueSize),
+		done:         make(chan struct{}),
+	}
+
+	go tracer.run()
+
+	return tracer, nil
+}
+
+// Start starts a span as a child of the span in the context, or of the remote parent in the context, or else as the
+// root of a new trace. The returned context carries the new span.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := parentFromContext(ctx)
+
+	traceID := parent.TraceID
+	if !traceID.IsValid() {
+		traceID = newTraceID()
+	}
+
+	span := &Span{ //nolint:exhaustruct
+		tracer:       t,
+		name:         name,
+		kind:         kind,
+		spanContext:  SpanContext{TraceID: traceID, SpanID: newSpanID()},
+		parentSpanID: parent.SpanID,
+		start:        time.Now(),
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Shutdown exports the spans that have ended, and stops exporting. Spans that end later are dropped.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.mu.Lock()
+
+	if !t.closed {
+		t.closed = true
+		close(t.queue)
+	}
+
+	t.mu.Unlock()
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to export remaining spans: %w", ctx.Err())
+	}
+
+	if err := t.exporter.shutdown(); err != nil {
+		return fmt.Errorf("failed to shut down span exporter: %w", err)
+	}
+
+	return nil
+}
+
+func (t *Tracer) enqueue(span *Span) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed {
+		return
+	}
+
+	select {
+	case t.queue <- span:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// run exports spans whenever a full batch is queued, and otherwise every batch timeout, until the queue is closed.
+func (t *Tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.batchTimeout)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxBatchSize)
+
+	for {
+		select {
+		case span, ok := <-t.queue:
+			if !ok {
+				t.export(batch)
+
+				return
+			}
+
+			batch = append(batch, span)
+			if len(batch) < maxBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		t.export(batch)
+		batch = batch[:0]
+	}
+}
+
+func (t *Tracer) export(batch []*Span) {
+	if dropped := t.dropped.Swap(0); dropped > 0 {
+		slog.Warn("dropped spans because the export queue was full", "dropped", dropped)
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	payload, err := encode(t.resource, batch)
+	if err != nil {
+		slog.Error("failed to encode spans", "error", err)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	if err := t.exporter.export(ctx, payload); err != nil {
+		slog.Warn("failed to export spans", "spans", len(batch), "error", err)
+	}
+}
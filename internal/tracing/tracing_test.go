@@ -0,0 +1,181 @@
+package tracing_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tracing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		ok    bool
+	}{
+		{name: "valid", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: true},
+		{name: "not sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", ok: true},
+		{name: "unknown version", value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: false},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ok: false},
+		{name: "zero span id", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ok: false},
+		{name: "upper case", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01", ok: false},
+		{name: "short trace id", value: "00-4bf92f3577b34da6-00f067aa0ba902b7-01", ok: false},
+		{name: "empty", value: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sc, ok := tracing.ParseTraceparent(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("ParseTraceparent(%q) ok = %v, want %v", tt.value, ok, tt.ok)
+			}
+
+			if ok && sc.Traceparent()[:len(tt.value)-3] != tt.value[:len(tt.value)-3] {
+				t.Errorf("Traceparent() = %q, want the IDs of %q", sc.Traceparent(), tt.value)
+			}
+		})
+	}
+}
+
+func TestTransportPropagatesTraceContextAndExportsSpans(t *testing.T) {
+	t.Parallel()
+
+	var received http.Header
+
+	portkey := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer portkey.Close()
+
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+
+	tracer, err := tracing.New(config.Tracing{ //nolint:exhaustruct
+		Exporter:     config.TracingExporterFile,
+		ServiceName:  "portkey-mcp-server-test",
+		FilePath:     path,
+		BatchTimeout: time.Hour,
+	}, "1.2.3")
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+
+	client, _ := tracing.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := tracing.ContextWithRemoteParent(context.Background(), client)
+
+	ctx, toolSpan := tracer.Start(ctx, "tools/call prompts_list", tracing.SpanKindServer)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, portkey.URL+"/v1/prompts?page_size=1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: tracer.Transport(nil)}).Do(req) //nolint:exhaustruct
+	if err != nil {
+		t.Fatalf("failed to call portkey: %v", err)
+	}
+
+	resp.Body.Close()
+	toolSpan.End()
+
+	traceID := toolSpan.SpanContext().TraceID.String()
+	if traceID != client.TraceID.String() {
+		t.Errorf("tool span trace ID = %s, want the client's %s", traceID, client.TraceID)
+	}
+
+	if got := received.Get("X-Portkey-Trace-Id"); got != traceID {
+		t.Errorf("X-Portkey-Trace-Id = %q, want %q", got, traceID)
+	}
+
+	if got := received.Get("X-Portkey-Parent-Span-Id"); got != toolSpan.SpanContext().SpanID.String() {
+		t.Errorf("X-Portkey-Parent-Span-Id = %q, want the tool span's %s", got, toolSpan.SpanContext().SpanID)
+	}
+
+	if got := received.Get("X-Portkey-Span-Name"); got != "GET /prompts" {
+		t.Errorf("X-Portkey-Span-Name = %q, want %q", got, "GET /prompts")
+	}
+
+	upstream, ok := tracing.ParseTraceparent(received.Get("Traceparent"))
+	if !ok || upstream.TraceID != client.TraceID || upstream.SpanID.String() != received.Get("X-Portkey-Span-Id") {
+		t.Errorf("traceparent = %q, want the upstream span's IDs", received.Get("Traceparent"))
+	}
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shut down tracer: %v", err)
+	}
+
+	spans := readSpans(t, path)
+
+	want := map[string]string{
+		"tools/call prompts_list": client.SpanID.String(),
+		"GET /prompts":            toolSpan.SpanContext().SpanID.String(),
+	}
+
+	if len(spans) != len(want) {
+		t.Fatalf("exported %d spans, want %d", len(spans), len(want))
+	}
+
+	for _, span := range spans {
+		if span.TraceID != traceID {
+			t.Errorf("span %q trace ID = %s, want %s", span.Name, span.TraceID, traceID)
+		}
+
+		if span.ParentSpanID != want[span.Name] {
+			t.Errorf("span %q parent span ID = %s, want %s", span.Name, span.ParentSpanID, want[span.Name])
+		}
+	}
+}
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`      //nolint:tagliatelle
+	ParentSpanID string `json:"parentSpanId"` //nolint:tagliatelle
+	Name         string `json:"name"`
+}
+
+func readSpans(t *testing.T, path string) []exportedSpan {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open spans file: %v", err)
+	}
+	defer file.Close()
+
+	var spans []exportedSpan
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var request struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"` //nolint:tagliatelle
+			} `json:"resourceSpans"` //nolint:tagliatelle
+		}
+
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			t.Fatalf("failed to decode spans: %v", err)
+		}
+
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+
+	return spans
+}
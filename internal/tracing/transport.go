@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+)
+
+// Transport returns an http.RoundTripper that records a client span for each request made through next, which is
+// http.DefaultTransport if nil, and sends the span's trace context along with the request.
+func (t *Tracer) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{tracer: t, next: next}
+}
+
+type transport struct {
+	tracer *Tracer
+	next   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(),
+		req.Method+" "+metrics.EndpointTemplate(req.URL.EscapedPath()), SpanKindClient)
+	defer span.End()
+
+	span.SetAttributes(
+		String("http.request.method", req.Method),
+		String("server.address", req.URL.Hostname()),
+		String("url.full", req.URL.Scheme+"://"+req.URL.Host+req.URL.EscapedPath()),
+	)
+
+	// A RoundTripper must not modify the request it was given.
+	req = req.Clone(ctx)
+	injectHeaders(req.Header, span)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.SetError(err.Error())
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	span.SetAttributes(Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetError(strconv.Itoa(resp.StatusCode))
+	}
+
+	return resp, nil
+}
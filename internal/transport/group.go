@@ -0,0 +1,181 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+)
+
+// Group serves the same MCP server over several transports concurrently, along with the admin endpoints if they have
+// an address of their own.
+type Group struct {
+	members []member
+	checker *health.Checker
+
+	// admin is nil unless the admin endpoints have an address of their own.
+	admin Server
+
+	// stopped is closed once every transport has stopped without an error.
+	stopped chan struct{}
+}
+
+type member struct {
+	transport config.TransportType
+	server    Server
+}
+
+// NewGroup sets up every configured transport. If any of them fails, for example because its address is already in
+// use, the ones already set up are released and an error is returned, so startup fails before anything is served.
+func NewGroup(
+	cfg config.App,
+	mcpServer *server.MCPServer,
+	checker *health.Checker,
+	appMetrics *metrics.Metrics,
+) (*Group, error) {
+	group := &Group{
+		members: make([]member, 0, len(cfg.Transports)),
+		checker: checker,
+		admin:   nil,
+		stopped: make(chan struct{}),
+	}
+
+	authenticator, err := newAuthenticator(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transportType := range cfg.Transports {
+		srv, err := New(transportType, cfg, mcpServer, authenticator, checker)
+		if err != nil {
+			group.Shutdown(context.Background())
+
+			return nil, fmt.Errorf("failed to set up %s transport: %w", transportType, err)
+		}
+
+		group.members = append(group.members, member{transport: transportType, server: srv})
+	}
+
+	if cfg.Admin.Address != "" {
+		admin, err := newAdmin(cfg.Admin.Address, adminRoutes(cfg, mcpServer, checker), appMetrics)
+		if err != nil {
+			group.Shutdown(context.Background())
+
+			return nil, fmt.Errorf("failed to set up admin server: %w", err)
+		}
+
+		group.admin = admin
+	}
+
+	return group, nil
+}
+
+func newAuthenticator(cfg config.App) (*auth.Authenticator, error) {
+	servesHTTP := cfg.Transports.Has(config.TransportSSE) || cfg.Transports.Has(config.TransportStreamableHTTP)
+
+	if !cfg.Auth.Enabled() {
+		if servesHTTP {
+			slog.Warn("http transports are unauthenticated: anyone who can reach them can use the portkey api key")
+		}
+
+		return nil, nil //nolint:nilnil
+	}
+
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up authentication: %w", err)
+	}
+
+	return authenticator, nil
+}
+
+// Start serves every transport in its own goroutine. Errors from all of them are reported on the returned channel,
+// which is buffered so that a transport never blocks on reporting its error.
+func (g *Group) Start() <-chan error {
+	errChan := make(chan error, len(g.members)+1)
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Bool
+	)
+
+	for _, m := range g.members {
+		wg.Go(func() {
+			if err := m.server.Serve(); err != nil {
+				failed.Store(true)
+				errChan <- fmt.Errorf("%s server error: %w", m.transport, err)
+
+				return
+			}
+
+			slog.Info("server has stopped", "transport", m.transport)
+		})
+	}
+
+	// A transport that failed has reported its error already, which is what the caller should see.
+	go func() {
+		wg.Wait()
+
+		if !failed.Load() {
+			close(g.stopped)
+		}
+	}()
+
+	if g.admin != nil {
+		go func() {
+			if err := g.admin.Serve(); err != nil {
+				errChan <- fmt.Errorf("admin server error: %w", err)
+			}
+		}()
+	}
+
+	return errChan
+}
+
+// Stopped returns a channel that is closed once every transport has stopped without an error. HTTP transports only
+// stop when they are shut down, but stdio stops as soon as its client closes stdin, so with stdio as the only
+// transport, this is how the server knows that there is no one left to serve.
+func (g *Group) Stopped() <-chan struct{} {
+	return g.stopped
+}
+
+// Shutdown gracefully stops every transport concurrently, waiting until they have all stopped or ctx is done.
+// Readiness checks fail from the start, and the admin endpoints are only stopped last, so that they report the
+// shutdown for as long as possible.
+func (g *Group) Shutdown(ctx context.Context) {
+	g.checker.ShutDown()
+
+	var wg sync.WaitGroup
+
+	for _, m := range g.members {
+		wg.Go(func() {
+			slog.Info("shutting down server...", "transport", m.transport)
+
+			if err := m.server.Shutdown(ctx); err != nil {
+				slog.Warn("server shutdown error", "transport", m.transport, "error", err)
+
+				return
+			}
+
+			slog.Info("server has been shut down gracefully", "transport", m.transport)
+		})
+	}
+
+	wg.Wait()
+
+	if g.admin == nil {
+		return
+	}
+
+	if err := g.admin.Shutdown(ctx); err != nil {
+		slog.Warn("admin server shutdown error", "error", err)
+	}
+}
@@ -0,0 +1,225 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/breaker"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/transport"
+)
+
+func newConfig(transports ...config.TransportType) config.App {
+	var cfg config.App
+
+	cfg.Transports = transports
+	cfg.TransportSSE.Address = "127.0.0.1:0"
+	cfg.TransportStreamableHTTP = config.StreamableHTTPTransport{
+		Address:           "127.0.0.1:0",
+		EndpointPath:      "/mcp",
+		Stateless:         false,
+		Resumable:         true,
+		HeartbeatInterval: 30 * time.Second,
+		SessionIdleTTL:    30 * time.Minute,
+	}
+
+	cfg.Admin = config.Admin{
+		Address:           "",
+		ReadinessCacheTTL: time.Minute,
+		ReadinessTimeout:  time.Second,
+	}
+
+	return cfg
+}
+
+// newChecker returns a readiness checker that doesn't call Portkey, since no API key is configured.
+func newChecker(cfg config.App) *health.Checker {
+	return health.NewChecker(cfg.Admin, cfg.Portkey, http.DefaultClient, breaker.New())
+}
+
+func TestNewGroupFailsWhenAddressInUse(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	cfg := newConfig(config.TransportStreamableHTTP, config.TransportSSE)
+	cfg.TransportSSE.Address = listener.Addr().String()
+
+	group, err := transport.NewGroup(cfg, server.NewMCPServer("test", "v1.0.0"), newChecker(cfg), metrics.New())
+	if err == nil {
+		group.Shutdown(context.Background())
+		t.Fatal("expected an error when the sse address is already in use")
+	}
+}
+
+func TestGroupServesAllTransportsUntilShutdown(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(config.TransportSSE, config.TransportStreamableHTTP)
+
+	group, err := transport.NewGroup(cfg, server.NewMCPServer("test", "v1.0.0"), newChecker(cfg), metrics.New())
+	if err != nil {
+		t.Fatalf("failed to set up transports: %v", err)
+	}
+
+	errChan := group.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group.Shutdown(ctx)
+
+	select {
+	case err := <-errChan:
+		t.Fatalf("unexpected server error: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestGroupStopsWhenStdioClientLeaves makes sure that the group reports that it has stopped once the stdio client
+// closes stdin, if stdio is the only transport, and that HTTP transports keep it going otherwise. It replaces
+// os.Stdin, so it can't run in parallel.
+func TestGroupStopsWhenStdioClientLeaves(t *testing.T) {
+	tests := []struct {
+		name        string
+		transports  []config.TransportType
+		wantStopped bool
+	}{
+		{
+			name:        "stdio only",
+			transports:  []config.TransportType{config.TransportStdio},
+			wantStopped: true,
+		},
+		{
+			name:        "stdio and streamable http",
+			transports:  []config.TransportType{config.TransportStdio, config.TransportStreamableHTTP},
+			wantStopped: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin, client, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("failed to create pipe: %v", err)
+			}
+
+			originalStdin := os.Stdin
+			os.Stdin = stdin
+
+			t.Cleanup(func() {
+				os.Stdin = originalStdin
+				_ = stdin.Close()
+			})
+
+			cfg := newConfig(tt.transports...)
+
+			group, err := transport.NewGroup(cfg, server.NewMCPServer("test", "v1.0.0"), newChecker(cfg), metrics.New())
+			if err != nil {
+				t.Fatalf("failed to set up transports: %v", err)
+			}
+
+			errChan := group.Start()
+
+			_ = client.Close()
+
+			wait := 5 * time.Second
+			if !tt.wantStopped {
+				wait = 200 * time.Millisecond
+			}
+
+			select {
+			case err := <-errChan:
+				t.Fatalf("unexpected server error: %v", err)
+			case <-group.Stopped():
+				if !tt.wantStopped {
+					t.Fatal("expected the http transport to keep the group going")
+				}
+			case <-time.After(wait):
+				if tt.wantStopped {
+					t.Fatal("expected the group to stop once the stdio client closed stdin")
+				}
+			}
+
+			group.Shutdown(context.Background())
+
+			select {
+			case <-group.Stopped():
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the group to be stopped after shutdown")
+			}
+		})
+	}
+}
+
+func TestNewUnknownTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := transport.New("carrier-pigeon", newConfig(), server.NewMCPServer("test", "v1.0.0"), nil, nil)
+	if err == nil {
+		t.Fatal("expected an error for an unknown transport")
+	}
+}
+
+func TestGroupServesAdminEndpointsUntilShutdown(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(config.TransportStreamableHTTP)
+	cfg.Admin.Address = freeAddress(t)
+	cfg.AppVersion = "v1.0.0"
+
+	checker := newChecker(cfg)
+
+	group, err := transport.NewGroup(cfg, server.NewMCPServer("test", "v1.0.0"), checker, metrics.New())
+	if err != nil {
+		t.Fatalf("failed to set up transports: %v", err)
+	}
+
+	group.Start()
+
+	for _, path := range []string{"/healthz", "/readyz", "/version", "/metrics"} {
+		if status := getStatus(t, "http://"+cfg.Admin.Address+path); status != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", path, status)
+		}
+	}
+
+	// Readiness fails as soon as shutdown starts, while the admin endpoints are still served.
+	checker.ShutDown()
+
+	if status := getStatus(t, "http://"+cfg.Admin.Address+"/readyz"); status != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503 during shutdown, got %d", status)
+	}
+
+	group.Shutdown(context.Background())
+
+	if _, err := http.Get("http://" + cfg.Admin.Address + "/healthz"); err == nil {
+		t.Error("expected the admin endpoints to be stopped after shutdown")
+	}
+}
+
+func getStatus(t *testing.T, url string) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("failed to get %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode
+}
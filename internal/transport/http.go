@@ -0,0 +1,187 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/apikey"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tools/middleware"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/tracing"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+// mcpHTTPServer is implemented by mcp-go's HTTP transports. Their Shutdown closes open sessions before shutting down
+// the underlying http.Server.
+type mcpHTTPServer interface {
+	Shutdown(ctx context.Context) error
+}
+
+type httpTransport struct {
+	name       string
+	listener   net.Listener
+	httpServer *http.Server
+	mcpServer  mcpHTTPServer
+
+	// certReloader is nil when the transport serves plaintext HTTP.
+	certReloader *certReloader
+	tlsCfg       config.TLS
+
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+}
+
+func newHTTPServer() *http.Server {
+	return &http.Server{ //nolint:exhaustruct
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+}
+
+// httpContextFunc builds the context of each HTTP request: its request-scoped logger, the trace context that the
+// client sent and the keys that the client sent in the headers of the Portkey profiles that accept client keys, given
+// by profile name.
+func httpContextFunc(clientKeyHeaders map[string]string) server.HTTPContextFunc {
+	withLoggingAndTracing := func(ctx context.Context, r *http.Request) context.Context {
+		return tracing.WithHTTPTraceContext(middleware.WithHTTPRequestLogging(ctx, r), r)
+	}
+
+	if len(clientKeyHeaders) == 0 {
+		return withLoggingAndTracing
+	}
+
+	withHeader := apikey.WithHTTPHeaders(clientKeyHeaders)
+
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return withHeader(withLoggingAndTracing(ctx, r), r)
+	}
+}
+
+// adminRoutes returns the function that adds the health, readiness and version endpoints to a mux.
+func adminRoutes(cfg config.App, mcpServer *server.MCPServer, checker *health.Checker) func(mux *http.ServeMux) {
+	return func(mux *http.ServeMux) {
+		health.Handle(mux, checker, cfg.AppVersion, mcpServer)
+	}
+}
+
+// newMux serves handler at pattern, so that only authenticated requests reach it unless authenticator is nil. When
+// OAuth is enabled, the protected resource metadata is served alongside it. The routes that are added to the mux
+// first, like the health endpoints, are served without authentication.
+func newMux(
+	pattern string,
+	handler http.Handler,
+	authenticator *auth.Authenticator,
+	routes func(mux *http.ServeMux),
+) *http.ServeMux {
+	mux := http.NewServeMux()
+	routes(mux)
+
+	if authenticator == nil {
+		mux.Handle(pattern, handler)
+
+		return mux
+	}
+
+	mux.Handle(pattern, authenticator.Middleware(handler))
+	authenticator.HandleMetadata(mux)
+
+	return mux
+}
+
+func listen(
+	name, address string,
+	tlsCfg config.TLS,
+	httpServer *http.Server,
+	mcpServer mcpHTTPServer,
+) (*httpTransport, error) {
+	var reloader *certReloader
+
+	if tlsCfg.Enabled() {
+		var err error
+
+		reloader, err = newCertReloader(name, tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", address, err)
+	}
+
+	httpServer.Addr = listener.Addr().String()
+
+	if reloader != nil {
+		listener = tls.NewListener(listener, reloader.TLSConfig())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &httpTransport{
+		name:         name,
+		listener:     listener,
+		httpServer:   httpServer,
+		mcpServer:    mcpServer,
+		certReloader: reloader,
+		tlsCfg:       tlsCfg,
+		ctx:          ctx,
+		cancel:       cancel,
+	}, nil
+}
+
+func (t *httpTransport) Serve() error {
+	slog.Info("starting "+t.name+" server",
+		"address", t.httpServer.Addr,
+		"tls", t.tlsCfg.Enabled(),
+		"mtls", t.tlsCfg.ClientCACertPath != "")
+
+	if t.certReloader != nil {
+		go t.certReloader.Run(t.ctx)
+	}
+
+	if err := t.httpServer.Serve(t.listener); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve %s: %w", t.name, err)
+	}
+
+	return nil
+}
+
+func (t *httpTransport) Shutdown(ctx context.Context) error {
+	t.cancel()
+
+	if err := t.mcpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down %s server: %w", t.name, err)
+	}
+
+	// Serve closes the listener when it returns, but a transport that was never served still holds it.
+	if err := t.listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("failed to close %s listener: %w", t.name, err)
+	}
+
+	return nil
+}
+
+// newAdmin serves the admin endpoints on a listener of their own, along with the metrics, which aren't served by the
+// HTTP transports.
+func newAdmin(address string, routes func(mux *http.ServeMux), appMetrics *metrics.Metrics) (*httpTransport, error) {
+	httpServer := newHTTPServer()
+
+	mux := http.NewServeMux()
+	routes(mux)
+	mux.Handle("GET /metrics", appMetrics.Handler())
+	httpServer.Handler = mux
+
+	return listen("admin", address, config.TLS{}, httpServer, httpServer) //nolint:exhaustruct
+}
@@ -0,0 +1,31 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+func newSSE(
+	cfg config.SSETransport,
+	mcpServer *server.MCPServer,
+	authenticator *auth.Authenticator,
+	contextFunc server.HTTPContextFunc,
+	routes func(mux *http.ServeMux),
+) (*httpTransport, error) {
+	httpServer := newHTTPServer()
+
+	sseServer := server.NewSSEServer(
+		mcpServer,
+		server.WithSSEContextFunc(server.SSEContextFunc(contextFunc)),
+		server.WithHTTPServer(httpServer),
+	)
+
+	// The SSE server routes its own endpoints, so it is served from the root.
+	httpServer.Handler = newMux("/", sseServer, authenticator, routes)
+
+	return listen("sse", cfg.Address, cfg.TLS, httpServer, sseServer)
+}
@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type stdio struct {
+	server *server.StdioServer
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+}
+
+func newStdio(mcpServer *server.MCPServer) *stdio {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &stdio{
+		server: server.NewStdioServer(mcpServer),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *stdio) Serve() error {
+	slog.Info("starting stdio server")
+
+	// Shutdown cancels the context, which is how Listen is told to stop.
+	if err := s.server.Listen(s.ctx, os.Stdin, os.Stdout); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("failed to serve stdio: %w", err)
+	}
+
+	return nil
+}
+
+func (s *stdio) Shutdown(_ context.Context) error {
+	s.cancel()
+
+	return nil
+}
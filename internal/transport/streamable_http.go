@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+func newStreamableHTTP(
+	cfg config.StreamableHTTPTransport,
+	mcpServer *server.MCPServer,
+	authenticator *auth.Authenticator,
+	contextFunc server.HTTPContextFunc,
+	routes func(mux *http.ServeMux),
+) (*httpTransport, error) {
+	httpServer := newHTTPServer()
+
+	streamableServer := server.NewStreamableHTTPServer(
+		mcpServer,
+		append(streamableHTTPOptions(cfg, contextFunc), server.WithStreamableHTTPServer(httpServer))...,
+	)
+
+	httpServer.Handler = newMux(cfg.EndpointPath, streamableServer, authenticator, routes)
+
+	return listen("streamable http", cfg.Address, cfg.TLS, httpServer, streamableServer)
+}
+
+func streamableHTTPOptions(
+	cfg config.StreamableHTTPTransport,
+	contextFunc server.HTTPContextFunc,
+) []server.StreamableHTTPOption {
+	opts := []server.StreamableHTTPOption{
+		server.WithEndpointPath(cfg.EndpointPath),
+		server.WithHeartbeatInterval(cfg.HeartbeatInterval),
+		server.WithSessionIdleTTL(cfg.SessionIdleTTL),
+		server.WithHTTPContextFunc(contextFunc),
+	}
+
+	// Stateless servers issue no session IDs, so there is nothing for a client to resume.
+	if cfg.Stateless {
+		return append(opts, server.WithStateLess(true))
+	}
+
+	// Stateful servers reject requests whose Mcp-Session-Id they didn't issue, or that has since expired.
+	opts = append(opts, server.WithStateful(true))
+
+	if cfg.Resumable {
+		opts = append(opts, server.WithEventStore(server.NewInMemoryEventStore()))
+	}
+
+	return opts
+}
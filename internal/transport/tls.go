@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+)
+
+// certReloader holds the TLS config loaded from disk, and reloads it when any of its files change, so that renewed
+// certificates and client CAs are picked up without a restart.
+type certReloader struct {
+	name string
+	cfg  config.TLS
+
+	mu        sync.RWMutex
+	tlsConfig *tls.Config
+
+	// modTimes are the modification times of the files that tlsConfig was loaded from. Only Run reads them after
+	// construction.
+	modTimes []time.Time
+}
+
+func newCertReloader(name string, cfg config.TLS) (*certReloader, error) {
+	reloader := &certReloader{ //nolint:exhaustruct
+		name: name,
+		cfg:  cfg,
+	}
+
+	modTimes, err := reloader.statFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reloader.load(modTimes); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// TLSConfig returns the config to serve with. Every handshake uses the most recently loaded files.
+func (r *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{ //nolint:exhaustruct
+		MinVersion: uint16(r.cfg.MinVersion),
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return r.tlsConfig, nil
+		},
+	}
+}
+
+// Run checks the files for changes every reload interval, until ctx is done.
+func (r *certReloader) Run(ctx context.Context) {
+	if r.cfg.ReloadInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+func (r *certReloader) reloadIfChanged() {
+	lgr := slog.Default().With("transport", r.name)
+
+	modTimes, err := r.statFiles()
+	if err != nil {
+		lgr.Warn("failed to check tls files for changes", "error", err)
+
+		return
+	}
+
+	if slices.EqualFunc(modTimes, r.modTimes, time.Time.Equal) {
+		return
+	}
+
+	// A certificate that is half-written, or a key that doesn't match it yet, is retried on the next tick.
+	if err := r.load(modTimes); err != nil {
+		lgr.Error("failed to reload tls config, still serving the previous one", "error", err)
+
+		return
+	}
+
+	lgr.Info("reloaded tls config")
+}
+
+func (r *certReloader) load(modTimes []time.Time) error {
+	tlsConfig, err := r.cfg.FromConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load tls config: %w", err)
+	}
+
+	r.mu.Lock()
+	r.tlsConfig = tlsConfig
+	r.mu.Unlock()
+
+	r.modTimes = modTimes
+
+	return nil
+}
+
+func (r *certReloader) statFiles() ([]time.Time, error) {
+	paths := r.cfg.Paths()
+	modTimes := make([]time.Time, 0, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %q: %w", path, err)
+		}
+
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	return modTimes, nil
+}
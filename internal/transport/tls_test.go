@@ -0,0 +1,213 @@
+package transport_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/metrics"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/transport"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, commonName string, parent *testCert, isCA bool) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{ //nolint:exhaustruct
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName}, //nolint:exhaustruct
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, certPath, keyPath string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	writePEM(t, certPath, "CERTIFICATE", c.cert.Raw)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key} //nolint:exhaustruct
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}) //nolint:exhaustruct
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestStreamableHTTPMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	caPath := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCert(t, "test-ca", nil, true)
+	ca.write(t, caPath, filepath.Join(dir, "ca.key"))
+	newTestCert(t, "server-1", ca, false).write(t, certPath, keyPath)
+	client := newTestCert(t, "client", ca, false)
+
+	cfg := newConfig(config.TransportStreamableHTTP)
+	cfg.TransportStreamableHTTP.Address = freeAddress(t)
+	cfg.TransportStreamableHTTP.TLS = config.TLS{
+		CertPath:         certPath,
+		KeyPath:          keyPath,
+		ClientCACertPath: caPath,
+		MinVersion:       tls.VersionTLS12,
+		ReloadInterval:   10 * time.Millisecond,
+	}
+
+	group, err := transport.NewGroup(cfg, server.NewMCPServer("test", "v1.0.0"), newChecker(cfg), metrics.New())
+	if err != nil {
+		t.Fatalf("failed to set up transports: %v", err)
+	}
+
+	group.Start()
+
+	t.Cleanup(func() { group.Shutdown(context.Background()) })
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	url := "https://" + cfg.TransportStreamableHTTP.Address + "/mcp"
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18",` +
+		`"capabilities":{},"clientInfo":{"name":"test","version":"v1.0.0"}}}`
+
+	post := func(clientCerts []tls.Certificate) (*http.Response, error) {
+		httpClient := &http.Client{ //nolint:exhaustruct
+			Transport: &http.Transport{ //nolint:exhaustruct
+				TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: clientCerts}, //nolint:exhaustruct
+			},
+		}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+
+		return httpClient.Do(req)
+	}
+
+	resp, err := post([]tls.Certificate{client.tlsCertificate()})
+	if err != nil {
+		t.Fatalf("request with a client certificate failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if got := resp.TLS.PeerCertificates[0].Subject.CommonName; got != "server-1" {
+		t.Fatalf("expected server certificate server-1, got %s", got)
+	}
+
+	if resp, err := post(nil); err == nil {
+		resp.Body.Close()
+		t.Fatal("expected a request without a client certificate to be rejected")
+	}
+
+	// Renew the server certificate, and wait for it to be picked up without a restart.
+	newTestCert(t, "server-2", ca, false).write(t, certPath, keyPath)
+
+	future := time.Now().Add(time.Minute)
+	for _, path := range []string{certPath, keyPath} {
+		if err := os.Chtimes(path, future, future); err != nil {
+			t.Fatalf("failed to touch %s: %v", path, err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		resp, err := post([]tls.Certificate{client.tlsCertificate()})
+		if err != nil {
+			t.Fatalf("request after renewal failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.TLS.PeerCertificates[0].Subject.CommonName == "server-2" {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("renewed server certificate was not reloaded")
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,51 @@
+// Package transport serves an MCP server over one or more transports at once.
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/auth"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/config"
+	"github.com/rvoh-emccaleb/portkey-mcp-server/internal/health"
+)
+
+var ErrUnknownTransportType = errors.New("unknown transport type")
+
+// Server serves an MCP server over a single transport.
+type Server interface {
+	// Serve blocks until the transport stops. It returns nil once the transport has been shut down, or its client has
+	// gone away.
+	Serve() error
+
+	// Shutdown gracefully stops the transport, closing any open sessions.
+	Shutdown(ctx context.Context) error
+}
+
+// New sets up the given transport. HTTP transports bind their listener here, rather than in Serve, so that an address
+// that is already in use is reported before anything is served. HTTP requests are authenticated with authenticator,
+// unless it is nil. HTTP transports also serve the health, readiness and version endpoints, without authentication.
+func New(
+	transportType config.TransportType,
+	cfg config.App,
+	mcpServer *server.MCPServer,
+	authenticator *auth.Authenticator,
+	checker *health.Checker,
+) (Server, error) {
+	contextFunc := httpContextFunc(cfg.ClientKeyHeaders())
+	routes := adminRoutes(cfg, mcpServer, checker)
+
+	switch transportType {
+	case config.TransportStdio:
+		return newStdio(mcpServer), nil
+	case config.TransportSSE:
+		return newSSE(cfg.TransportSSE, mcpServer, authenticator, contextFunc, routes)
+	case config.TransportStreamableHTTP:
+		return newStreamableHTTP(cfg.TransportStreamableHTTP, mcpServer, authenticator, contextFunc, routes)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTransportType, transportType)
+	}
+}